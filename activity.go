@@ -0,0 +1,245 @@
+package codeforces
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+)
+
+// DayActivity is the activity of a user during a period, usually a day.
+type DayActivity struct {
+	// Date is the start of the period, at midnight in the time zone of the
+	// Activity.
+	Date time.Time
+
+	// Submissions is the number of submissions made.
+	Submissions int
+
+	// Accepted is the number of distinct problems accepted.
+	Accepted int
+}
+
+// Activity is the daily activity of a user in a time zone, computed from the
+// user's submissions.
+type Activity struct {
+	location *time.Location
+	days     map[string]*DayActivity
+	accepted map[string]map[ProblemID]bool
+}
+
+const dateLayout = "2006-01-02"
+
+// NewActivity computes the daily activity from submissions, as returned by
+// GetUserStatus, bucketing days in loc. A nil loc means UTC.
+func NewActivity(submissions []Submission, loc *time.Location) *Activity {
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	a := &Activity{
+		location: loc,
+		days:     make(map[string]*DayActivity),
+		accepted: make(map[string]map[ProblemID]bool),
+	}
+
+	for _, submission := range submissions {
+		date := a.date(time.Unix(int64(submission.CreationTimeSeconds), 0))
+		key := date.Format(dateLayout)
+
+		day, ok := a.days[key]
+		if !ok {
+			day = &DayActivity{Date: date}
+			a.days[key] = day
+			a.accepted[key] = make(map[ProblemID]bool)
+		}
+
+		day.Submissions++
+		if submission.Verdict == "OK" {
+			id := submission.Problem.ProblemID()
+			if !a.accepted[key][id] {
+				a.accepted[key][id] = true
+				day.Accepted++
+			}
+		}
+	}
+
+	return a
+}
+
+// GetActivity returns the daily activity of a user, bucketing days in loc.
+func (c *Client) GetActivity(handle string, loc *time.Location) (*Activity, error) {
+	submissions, err := c.GetUserStatus(handle, 1, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewActivity(submissions, loc), nil
+}
+
+// GetActivity returns the daily activity of a user, bucketing days in loc.
+//
+// GetActivity is a wrapper around DefaultClient.GetActivity.
+func GetActivity(handle string, loc *time.Location) (*Activity, error) {
+	return DefaultClient.GetActivity(handle, loc)
+}
+
+// date returns midnight of the day of t.
+func (a *Activity) date(t time.Time) time.Time {
+	t = t.In(a.location)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, a.location)
+}
+
+// Day returns the activity during the day of t.
+func (a *Activity) Day(t time.Time) DayActivity {
+	date := a.date(t)
+	if day, ok := a.days[date.Format(dateLayout)]; ok {
+		return *day
+	}
+	return DayActivity{Date: date}
+}
+
+// Days returns the days with at least one submission, in chronological order.
+func (a *Activity) Days() []DayActivity {
+	res := make([]DayActivity, 0, len(a.days))
+	for _, day := range a.days {
+		res = append(res, *day)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Date.Before(res[j].Date)
+	})
+
+	return res
+}
+
+// CurrentStreak returns the number of consecutive days with at least one
+// submission ending on the day of now, or on the day before if there was no
+// submission yet on the day of now.
+func (a *Activity) CurrentStreak(now time.Time) int {
+	date := a.date(now)
+	if a.Day(date).Submissions == 0 {
+		date = date.AddDate(0, 0, -1)
+	}
+
+	streak := 0
+	for a.Day(date).Submissions > 0 {
+		streak++
+		date = date.AddDate(0, 0, -1)
+	}
+
+	return streak
+}
+
+// LongestStreak returns the largest number of consecutive days with at least
+// one submission.
+func (a *Activity) LongestStreak() int {
+	longest, streak := 0, 0
+
+	var last time.Time
+	for _, day := range a.Days() {
+		if !last.IsZero() && last.AddDate(0, 0, 1).Equal(day.Date) {
+			streak++
+		} else {
+			streak = 1
+		}
+		last = day.Date
+
+		if streak > longest {
+			longest = streak
+		}
+	}
+
+	return longest
+}
+
+// Weekly returns the activity aggregated by week, weeks starting on Monday.
+// Weeks without submissions are omitted.
+func (a *Activity) Weekly() []DayActivity {
+	return a.aggregate(func(date time.Time) time.Time {
+		return date.AddDate(0, 0, -(int(date.Weekday())+6)%7)
+	})
+}
+
+// Monthly returns the activity aggregated by month. Months without
+// submissions are omitted.
+func (a *Activity) Monthly() []DayActivity {
+	return a.aggregate(func(date time.Time) time.Time {
+		return time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, a.location)
+	})
+}
+
+// aggregate sums the daily activity into periods starting at period(day).
+// Accepted problems are counted once per day they were accepted.
+func (a *Activity) aggregate(period func(time.Time) time.Time) []DayActivity {
+	var res []DayActivity
+	for _, day := range a.Days() {
+		start := period(day.Date)
+		if len(res) == 0 || !res[len(res)-1].Date.Equal(start) {
+			res = append(res, DayActivity{Date: start})
+		}
+
+		res[len(res)-1].Submissions += day.Submissions
+		res[len(res)-1].Accepted += day.Accepted
+	}
+
+	return res
+}
+
+var heatmapColors = []string{"#ebedf0", "#9be9a8", "#40c463", "#30a14e", "#216e39"}
+
+const (
+	heatmapCell = 10
+	heatmapGap  = 2
+)
+
+// WriteSVG writes a heatmap of the daily number of submissions from the day of
+// from to the day of to as a SVG image to w, one column per week.
+func (a *Activity) WriteSVG(w io.Writer, from, to time.Time) error {
+	start := a.date(from)
+	start = start.AddDate(0, 0, -(int(start.Weekday())+6)%7)
+	end := a.date(to)
+
+	most := 0
+	for date := start; !date.After(end); date = date.AddDate(0, 0, 1) {
+		if n := a.Day(date).Submissions; n > most {
+			most = n
+		}
+	}
+
+	weeks := 0
+	for date := start; !date.After(end); date = date.AddDate(0, 0, 7) {
+		weeks++
+	}
+
+	step := heatmapCell + heatmapGap
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintf(bw, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d">`+"\n", weeks*step, 7*step)
+
+	week := 0
+	for date := start; !date.After(end); date = date.AddDate(0, 0, 1) {
+		row := (int(date.Weekday()) + 6) % 7
+		if row == 0 && date.After(start) {
+			week++
+		}
+		if date.Before(a.date(from)) {
+			continue
+		}
+
+		day := a.Day(date)
+
+		level := 0
+		if day.Submissions > 0 {
+			level = 1 + 4*(day.Submissions-1)/most
+		}
+
+		fmt.Fprintf(bw, `<rect x="%d" y="%d" width="%d" height="%d" rx="2" fill="%s"><title>%s: %d submissions, %d accepted</title></rect>`+"\n",
+			week*step, row*step, heatmapCell, heatmapCell, heatmapColors[level], date.Format(dateLayout), day.Submissions, day.Accepted)
+	}
+
+	fmt.Fprintln(bw, "</svg>")
+
+	return bw.Flush()
+}
@@ -0,0 +1,104 @@
+package codeforces
+
+import (
+	"sync"
+)
+
+// defaultBatchConcurrency is the number of calls of a Batch run at the same
+// time when no concurrency is given.
+const defaultBatchConcurrency = 4
+
+// BatchResult is the outcome of a call of a Batch.
+type BatchResult struct {
+	// Value is the value returned by the call. It is the v given to AddCall
+	// for calls added with AddCall.
+	Value interface{}
+
+	// Err is the error returned by the call, if any.
+	Err error
+}
+
+// BatchResults are the results of the calls of a Batch, in the order the
+// calls were added.
+type BatchResults []BatchResult
+
+// Batch runs many, possibly different, API calls of a client concurrently.
+// Calls go through the client and therefore honor its rate limit; identical
+// calls are coalesced.
+type Batch struct {
+	client      *Client
+	concurrency int
+	calls       []func(c *Client) (interface{}, error)
+}
+
+// NewBatch creates a Batch running at most concurrency calls at the same time.
+// Set concurrency to 0 to use a default of 4.
+func (c *Client) NewBatch(concurrency int) *Batch {
+	if concurrency <= 0 {
+		concurrency = defaultBatchConcurrency
+	}
+
+	return &Batch{client: c, concurrency: concurrency}
+}
+
+// NewBatch creates a Batch running at most concurrency calls at the same time.
+//
+// NewBatch is a wrapper around DefaultClient.NewBatch.
+func NewBatch(concurrency int) *Batch {
+	return DefaultClient.NewBatch(concurrency)
+}
+
+// Add adds a call to the batch and returns its index in the results of Run.
+// f typically calls a single method of the client, for example:
+//
+//	b.Add(func(c *Client) (interface{}, error) { return c.GetUserRating(handle) })
+func (b *Batch) Add(f func(c *Client) (interface{}, error)) int {
+	b.calls = append(b.calls, f)
+	return len(b.calls) - 1
+}
+
+// AddCall adds a call of an arbitrary API method to the batch, decoding its
+// result into v, and returns its index in the results of Run. See Client.Call.
+func (b *Batch) AddCall(method string, params map[string][]string, v interface{}) int {
+	return b.Add(func(c *Client) (interface{}, error) {
+		return v, c.Call(method, params, v)
+	})
+}
+
+// Len returns the number of calls in the batch.
+func (b *Batch) Len() int {
+	return len(b.calls)
+}
+
+// Run runs every call of the batch and returns their results, in the order
+// the calls were added. A failed call does not stop the others.
+func (b *Batch) Run() BatchResults {
+	results := make(BatchResults, len(b.calls))
+	sem := make(chan struct{}, b.concurrency)
+
+	var wg sync.WaitGroup
+	for i, f := range b.calls {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, f func(c *Client) (interface{}, error)) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			v, err := f(b.client)
+			results[i] = BatchResult{Value: v, Err: err}
+		}(i, f)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// Err returns the first error of results, or nil if every call succeeded.
+func (r BatchResults) Err() error {
+	for _, res := range r {
+		if res.Err != nil {
+			return res.Err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,181 @@
+package codeforces
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// CalendarOptions selects the contests included in a calendar. The zero value
+// includes every upcoming contest.
+type CalendarOptions struct {
+	// ExcludeGym excludes gym contests.
+	ExcludeGym bool
+
+	// Kinds restricts gym contests to the given kinds. Leave empty to not
+	// filter by kind.
+	Kinds []string
+
+	// MinDifficulty and MaxDifficulty restrict gym contests to the given
+	// difficulty range. Set to 0 to leave a bound open.
+	MinDifficulty int
+	MaxDifficulty int
+
+	// Name restricts contests to those whose name matches. Set to nil to not
+	// filter by name.
+	Name *regexp.Regexp
+}
+
+func (o CalendarOptions) match(contest Contest) bool {
+	if contest.Phase != "BEFORE" || contest.StartTimeSeconds == 0 {
+		return false
+	}
+
+	if isGym(contest.ID) {
+		if o.ExcludeGym {
+			return false
+		}
+		if len(o.Kinds) > 0 && !containsString(o.Kinds, contest.Kind) {
+			return false
+		}
+		if o.MinDifficulty != 0 && contest.Difficulty < o.MinDifficulty {
+			return false
+		}
+		if o.MaxDifficulty != 0 && contest.Difficulty > o.MaxDifficulty {
+			return false
+		}
+	}
+
+	if o.Name != nil && !o.Name.MatchString(contest.Name) {
+		return false
+	}
+
+	return true
+}
+
+// WriteCalendar writes the upcoming contests among contests as an iCalendar
+// (RFC 5545) feed to w. Each contest becomes an event whose UID only depends on
+// the contest ID, so that calendar clients update events in place.
+func WriteCalendar(w io.Writer, contests []Contest, opts CalendarOptions) error {
+	bw := bufio.NewWriter(w)
+	now := time.Now().UTC()
+
+	writeCalendarLine(bw, "BEGIN:VCALENDAR")
+	writeCalendarLine(bw, "VERSION:2.0")
+	writeCalendarLine(bw, "PRODID:-//go-codeforces//Codeforces contests//EN")
+	writeCalendarLine(bw, "CALSCALE:GREGORIAN")
+	writeCalendarLine(bw, "METHOD:PUBLISH")
+	writeCalendarLine(bw, "X-WR-CALNAME:Codeforces contests")
+
+	for _, contest := range contests {
+		if !opts.match(contest) {
+			continue
+		}
+
+		start := time.Unix(int64(contest.StartTimeSeconds), 0).UTC()
+		end := start.Add(time.Duration(contest.DurationSeconds) * time.Second)
+		url := contest.URL()
+
+		writeCalendarLine(bw, "BEGIN:VEVENT")
+		writeCalendarLine(bw, fmt.Sprintf("UID:contest-%d@codeforces.com", contest.ID))
+		writeCalendarLine(bw, "DTSTAMP:"+formatCalendarTime(now))
+		writeCalendarLine(bw, "DTSTART:"+formatCalendarTime(start))
+		writeCalendarLine(bw, "DTEND:"+formatCalendarTime(end))
+		writeCalendarLine(bw, "SUMMARY:"+escapeCalendarText(contest.Name))
+		writeCalendarLine(bw, "DESCRIPTION:"+escapeCalendarText(url))
+		writeCalendarLine(bw, "URL:"+url)
+		writeCalendarLine(bw, "END:VEVENT")
+	}
+
+	writeCalendarLine(bw, "END:VCALENDAR")
+
+	return bw.Flush()
+}
+
+// CalendarHandler returns a http.Handler serving the upcoming contests as an
+// iCalendar feed. Contest lists are fetched on every request.
+func (c *Client) CalendarHandler(opts CalendarOptions) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var contests []Contest
+		var err error
+		if opts.ExcludeGym {
+			contests, err = c.GetContestList(false)
+		} else {
+			contests, err = c.GetAllContestList()
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+
+		// Writes to a bytes.Buffer do not fail, and a failed write to w
+		// means the client went away.
+		var buf bytes.Buffer
+		WriteCalendar(&buf, contests, opts)
+
+		w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+		buf.WriteTo(w)
+	})
+}
+
+// CalendarHandler returns a http.Handler serving the upcoming contests as an
+// iCalendar feed.
+//
+// CalendarHandler is a wrapper around DefaultClient.CalendarHandler.
+func CalendarHandler(opts CalendarOptions) http.Handler {
+	return DefaultClient.CalendarHandler(opts)
+}
+
+func formatCalendarTime(t time.Time) string {
+	return t.Format("20060102T150405Z")
+}
+
+var calendarTextEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	";", `\;`,
+	",", `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+)
+
+func escapeCalendarText(s string) string {
+	return calendarTextEscaper.Replace(s)
+}
+
+// writeCalendarLine writes a content line terminated by CRLF, folding it so
+// that no physical line is longer than 75 octets.
+func writeCalendarLine(w *bufio.Writer, line string) {
+	limit := 75
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+
+		w.WriteString(line[:cut])
+		w.WriteString("\r\n ")
+		line = line[cut:]
+
+		// Continuation lines start with a space, which counts towards the
+		// limit.
+		limit = 74
+	}
+
+	w.WriteString(line)
+	w.WriteString("\r\n")
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
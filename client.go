@@ -1,10 +1,10 @@
 package codeforces
 
 import (
+	"context"
 	"crypto/sha512"
 	"encoding/hex"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io/ioutil"
 	"math/rand"
@@ -13,14 +13,29 @@ import (
 	"path"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
+// defaultBaseURL is the base URL of the Codeforces API.
+const defaultBaseURL = "http://codeforces.com/api/"
+
 type Client struct {
 	apiKey     *string
 	apiSecret  *string
 	locale     *string
+	baseURL    *string
 	httpClient *http.Client
+
+	rateLimitMu       sync.Mutex
+	rateLimitInterval time.Duration
+	nextCallTime      time.Time
+
+	hooks []Hook
+
+	noCoalescing bool
+	inflightMu   sync.Mutex
+	inflight     map[string]*inflightCall
 }
 
 type apiResponse struct {
@@ -29,6 +44,23 @@ type apiResponse struct {
 	Result  json.RawMessage `json:"result,omitempty"`
 }
 
+// APIError is returned when the Codeforces API answers a call with status
+// "FAILED".
+type APIError struct {
+	// Method is the API method called.
+	Method string
+
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+
+	// Comment is the reason of the failure given by Codeforces.
+	Comment string
+}
+
+func (e *APIError) Error() string {
+	return e.Comment
+}
+
 // DefaultClient is the default Client and is used by GetBlogEntryComments,
 // GetBlogEntry, GetContestHacks, GetContestList, GetContestRatingChanges,
 // GetContestStandings, GetContestStatus, GetProblemsetProblems,
@@ -58,10 +90,49 @@ func (c *Client) getAPISig(method string, params map[string][]string) (string, e
 	return r + apiSig, nil
 }
 
-func (c *Client) makeAPICall(method string, params map[string][]string, v interface{}) error {
-	u, err := url.Parse("http://codeforces.com/api/")
+// waitRateLimit blocks until the client is allowed to make the next API call
+// or ctx is done.
+func (c *Client) waitRateLimit(ctx context.Context) error {
+	c.rateLimitMu.Lock()
+	now := time.Now()
+	callTime := c.nextCallTime
+	if callTime.Before(now) {
+		callTime = now
+	}
+	c.nextCallTime = callTime.Add(c.rateLimitInterval)
+	c.rateLimitMu.Unlock()
+
+	if callTime.Equal(now) {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(callTime.Sub(now))
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// doAPIRequest sends the request for an API call, once the rate limit allows
+// it, and returns the raw response. Hooks are notified once the request is
+// signed; the caller must call c.finishCall when done with the response.
+func (c *Client) doAPIRequest(ctx context.Context, call *APICall, params map[string][]string) (*http.Response, error) {
+	if err := c.waitRateLimit(ctx); err != nil {
+		return nil, err
+	}
+
+	base := defaultBaseURL
+	if c.baseURL != nil {
+		base = *c.baseURL
+	}
+
+	u, err := url.Parse(base)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if c.locale != nil {
@@ -72,9 +143,9 @@ func (c *Client) makeAPICall(method string, params map[string][]string, v interf
 		params["time"] = []string{strconv.FormatInt(time.Now().Unix(), 10)}
 		params["apiKey"] = []string{*c.apiKey}
 
-		apiSig, err := c.getAPISig(method, params)
+		apiSig, err := c.getAPISig(call.Method, params)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		params["apiSig"] = []string{apiSig}
@@ -85,34 +156,175 @@ func (c *Client) makeAPICall(method string, params map[string][]string, v interf
 		q.Set(k, strings.Join(v, ";"))
 	}
 
-	u.Path = path.Join(u.Path, method)
+	u.Path = path.Join(u.Path, call.Method)
 	u.RawQuery = q.Encode()
 
-	resp, err := c.httpClient.Get(u.String())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	defer resp.Body.Close()
 
+	c.startCall(call, params)
+
+	resp, err := c.httpClient.Do(req)
+	if err == nil {
+		call.HTTPStatus = resp.StatusCode
+		resp.Body = &countingReader{ReadCloser: resp.Body, n: &call.Bytes}
+	}
+	return resp, err
+}
+
+func (c *Client) makeAPICall(method string, params map[string][]string, v interface{}) error {
+	return c.makeAPICallContext(context.Background(), method, params, v)
+}
+
+// makeAPICallContext is like makeAPICall but gives up waiting for the rate
+// limit or the response once ctx is done. Calls with a cancelable context are
+// not coalesced, so that canceling one does not fail the identical calls of
+// other callers.
+func (c *Client) makeAPICallContext(ctx context.Context, method string, params map[string][]string, v interface{}) error {
+	fetch := func() (json.RawMessage, error) {
+		return c.fetchAPIResult(ctx, method, params)
+	}
+
+	var result json.RawMessage
+	var err error
+	if ctx.Done() == nil {
+		result, err = c.coalesce(method, params, fetch)
+	} else {
+		result, err = fetch()
+	}
 	if err != nil {
 		return err
 	}
 
+	return json.Unmarshal(result, v)
+}
+
+// fetchAPIResult makes an API call and returns its undecoded result.
+func (c *Client) fetchAPIResult(ctx context.Context, method string, params map[string][]string) (result json.RawMessage, err error) {
+	call := &APICall{Method: method}
+	defer func() { c.finishCall(call, err) }()
+
+	resp, err := c.doAPIRequest(ctx, call, params)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
 	body, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	var res apiResponse
 	if err := json.Unmarshal(body, &res); err != nil {
-		return err
+		return nil, err
 	}
 
+	call.Status = res.Status
+	call.Comment = res.Comment
 	if res.Status == "FAILED" {
-		return errors.New(res.Comment)
+		return nil, &APIError{Method: method, StatusCode: resp.StatusCode, Comment: res.Comment}
+	}
+
+	return res.Result, nil
+}
+
+// Call calls an arbitrary API method with the given parameters and decodes its
+// result into v. Use a *json.RawMessage to get the result undecoded.
+//
+// Failed calls return an *APIError.
+func (c *Client) Call(method string, params map[string][]string, v interface{}) error {
+	p := make(map[string][]string, len(params))
+	for k, v := range params {
+		p[k] = v
 	}
 
-	return json.Unmarshal(res.Result, v)
+	return c.makeAPICall(method, p, v)
+}
+
+// Call calls an arbitrary API method with the given parameters and decodes its
+// result into v.
+//
+// Call is a wrapper around DefaultClient.Call.
+func Call(method string, params map[string][]string, v interface{}) error {
+	return DefaultClient.Call(method, params, v)
+}
+
+// makeStreamingAPICall makes an API call whose result is a JSON array, calling
+// f with a decoder positioned on each element of the array in turn, without
+// reading the whole response in memory.
+func (c *Client) makeStreamingAPICall(method string, params map[string][]string, f func(dec *json.Decoder) error) (err error) {
+	call := &APICall{Method: method}
+	defer func() { c.finishCall(call, err) }()
+
+	resp, err := c.doAPIRequest(context.Background(), call, params)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	dec := json.NewDecoder(resp.Body)
+	if err := expectDelim(dec, '{'); err != nil {
+		return err
+	}
+
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+
+		switch tok {
+		case "status":
+			err = dec.Decode(&call.Status)
+		case "comment":
+			err = dec.Decode(&call.Comment)
+		case "result":
+			if call.Status == "FAILED" {
+				return &APIError{Method: method, StatusCode: resp.StatusCode, Comment: call.Comment}
+			}
+			err = decodeArray(dec, f)
+		default:
+			var skip json.RawMessage
+			err = dec.Decode(&skip)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if call.Status == "FAILED" {
+		return &APIError{Method: method, StatusCode: resp.StatusCode, Comment: call.Comment}
+	}
+
+	return nil
+}
+
+func decodeArray(dec *json.Decoder, f func(dec *json.Decoder) error) error {
+	if err := expectDelim(dec, '['); err != nil {
+		return err
+	}
+
+	for dec.More() {
+		if err := f(dec); err != nil {
+			return err
+		}
+	}
+
+	return expectDelim(dec, ']')
+}
+
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok != delim {
+		return fmt.Errorf("codeforces: expected %v in response, got %v", delim, tok)
+	}
+	return nil
 }
 
 // SetAPIKey sets apiKey and apiSecret of a client
@@ -125,3 +337,26 @@ func (c *Client) SetAPIKey(apiKey, apiSecret string) {
 func (c *Client) SetLocale(locale string) {
 	c.locale = &locale
 }
+
+// SetBaseURL sets the base URL API calls are made to, such as the address of a
+// proxy serving the same endpoints. It defaults to http://codeforces.com/api/.
+func (c *Client) SetBaseURL(baseURL string) {
+	c.baseURL = &baseURL
+}
+
+// SetHTTPClient sets the HTTP client used to make API calls.
+func (c *Client) SetHTTPClient(httpClient *http.Client) {
+	c.httpClient = httpClient
+}
+
+// SetRateLimit limits a client to at most one API call per interval. Calls
+// made while the limit is exhausted block until they are allowed. Set interval
+// to 0 to disable rate limiting.
+//
+// Codeforces allows at most one call every two seconds.
+func (c *Client) SetRateLimit(interval time.Duration) {
+	c.rateLimitMu.Lock()
+	defer c.rateLimitMu.Unlock()
+
+	c.rateLimitInterval = interval
+}
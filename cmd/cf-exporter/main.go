@@ -0,0 +1,262 @@
+// Command cf-exporter exports Codeforces metrics of users and contests for
+// Prometheus.
+//
+// Usage:
+//
+//	cf-exporter [--addr host:port] [--interval d] [--rate d] handle...
+//
+// Every interval, cf-exporter refreshes the given handles with user.info and
+// user.status, and the upcoming contests with contest.list. Metrics are served
+// on /metrics in the Prometheus text format:
+//
+//	codeforces_user_rating{handle}
+//	codeforces_user_max_rating{handle}
+//	codeforces_user_solved_total{handle}
+//	codeforces_user_submissions_total{handle,verdict,language}
+//	codeforces_contest_upcoming_start_seconds{contest_id,name}
+//
+// along with metrics of the API calls made: codeforces_api_calls_total by
+// method and API status, codeforces_api_call_duration_seconds and
+// codeforces_api_retries_total. Failed calls are retried up to three times,
+// except those rejected by the API for a reason other than the call limit.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mukundan314/go-codeforces"
+)
+
+// maxAttempts is the number of attempts of an API call.
+const maxAttempts = 3
+
+// statusPageSize is the number of submissions fetched per user.status call
+// after the first refresh.
+const statusPageSize = 100
+
+func main() {
+	addr := flag.String("addr", "localhost:9712", "address to listen on")
+	interval := flag.Duration("interval", 5*time.Minute, "refresh interval")
+	rate := flag.Duration("rate", 2*time.Second, "minimum interval between API calls")
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: cf-exporter [flags] handle...")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	reg := newRegistry()
+	registerMetrics(reg)
+
+	client := codeforces.NewClient()
+	client.SetRateLimit(*rate)
+	client.AddHook(newMetricsHook(reg))
+
+	e := newExporter(client, reg, flag.Args())
+	go func() {
+		for {
+			e.refresh()
+			time.Sleep(*interval)
+		}
+	}()
+
+	http.Handle("/metrics", reg)
+	log.Printf("cf-exporter listening on %s", *addr)
+	log.Fatal(http.ListenAndServe(*addr, nil))
+}
+
+func registerMetrics(reg *registry) {
+	reg.register("codeforces_user_rating", "gauge", "Current rating of the user.")
+	reg.register("codeforces_user_max_rating", "gauge", "Maximum rating of the user.")
+	reg.register("codeforces_user_solved_total", "gauge", "Number of distinct problems solved by the user.")
+	reg.register("codeforces_user_submissions_total", "counter", "Submissions of the user by verdict and language.")
+	reg.register("codeforces_contest_upcoming_start_seconds", "gauge", "Start time of upcoming contests, in seconds since the epoch.")
+	reg.register("codeforces_api_calls_total", "counter", "API calls by method and API status.")
+	reg.register("codeforces_api_call_duration_seconds", "summary", "Duration of API calls.")
+	reg.register("codeforces_api_retries_total", "counter", "Retried API calls by method.")
+	reg.register("codeforces_exporter_refresh_errors_total", "counter", "Refreshes that failed.")
+}
+
+// exporter refreshes the metrics of a set of handles.
+type exporter struct {
+	client  *codeforces.Client
+	reg     *registry
+	handles []string
+
+	// submissions holds the submissions seen so far of each handle, by ID.
+	submissions map[string]map[int]codeforces.Submission
+}
+
+func newExporter(client *codeforces.Client, reg *registry, handles []string) *exporter {
+	return &exporter{
+		client:      client,
+		reg:         reg,
+		handles:     handles,
+		submissions: make(map[string]map[int]codeforces.Submission),
+	}
+}
+
+// refresh refreshes every metric, logging errors.
+func (e *exporter) refresh() {
+	for _, f := range []func() error{e.refreshUsers, e.refreshSubmissions, e.refreshContests} {
+		if err := f(); err != nil {
+			log.Print(err)
+			e.reg.add("codeforces_exporter_refresh_errors_total", nil, 1)
+		}
+	}
+}
+
+// retry calls f up to maxAttempts times, until it succeeds or fails with an
+// API error other than the call limit.
+func (e *exporter) retry(method string, f func() error) error {
+	var err error
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		if attempt > 1 {
+			e.reg.add("codeforces_api_retries_total", []string{"method", method}, 1)
+			time.Sleep(time.Duration(attempt) * 2 * time.Second)
+		}
+
+		err = f()
+
+		var apiErr *codeforces.APIError
+		if err == nil || (errors.As(err, &apiErr) && !strings.Contains(strings.ToLower(apiErr.Comment), "call limit exceeded")) {
+			return err
+		}
+	}
+	return err
+}
+
+func (e *exporter) refreshUsers() error {
+	var users []codeforces.User
+	err := e.retry("user.info", func() (err error) {
+		users, err = e.client.GetUserInfo(e.handles)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, u := range users {
+		e.reg.set("codeforces_user_rating", []string{"handle", u.Handle}, float64(u.Rating))
+		e.reg.set("codeforces_user_max_rating", []string{"handle", u.Handle}, float64(u.MaxRating))
+	}
+	return nil
+}
+
+func (e *exporter) refreshSubmissions() error {
+	// A failing handle is logged and skipped so that the metrics of the
+	// others stay fresh.
+	failed := 0
+	for _, handle := range e.handles {
+		if err := e.refreshUserSubmissions(handle); err != nil {
+			log.Printf("%s: %v", handle, err)
+			failed++
+		}
+	}
+
+	submissions := make(map[string]float64)
+	solved := make(map[string]float64)
+	for handle, subs := range e.submissions {
+		problems := make(map[codeforces.ProblemID]bool)
+		for _, s := range subs {
+			if isPending(s) {
+				continue
+			}
+			submissions[formatLabels([]string{"handle", handle, "verdict", s.Verdict, "language", s.ProgrammingLanguage})]++
+			if s.Verdict == "OK" {
+				problems[s.Problem.ProblemID()] = true
+			}
+		}
+		solved[formatLabels([]string{"handle", handle})] = float64(len(problems))
+	}
+
+	e.reg.replace("codeforces_user_submissions_total", submissions)
+	e.reg.replace("codeforces_user_solved_total", solved)
+
+	if failed > 0 {
+		return fmt.Errorf("refreshing the submissions of %d of %d handles failed", failed, len(e.handles))
+	}
+	return nil
+}
+
+// refreshUserSubmissions fetches all submissions of a handle the first time,
+// then only the most recent pages, back to the newest submission already seen
+// or the oldest one still pending.
+func (e *exporter) refreshUserSubmissions(handle string) error {
+	seen, ok := e.submissions[handle]
+	count, boundary := 0, 0
+	if ok {
+		count = statusPageSize
+		for id := range seen {
+			if id > boundary {
+				boundary = id
+			}
+		}
+		for id, s := range seen {
+			if isPending(s) && id < boundary {
+				boundary = id
+			}
+		}
+	} else {
+		seen = make(map[int]codeforces.Submission)
+	}
+
+	for from := 1; ; from += statusPageSize {
+		var page []codeforces.Submission
+		err := e.retry("user.status", func() (err error) {
+			page, err = e.client.GetUserStatus(handle, from, count)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, s := range page {
+			seen[s.ID] = s
+		}
+
+		if count == 0 || len(page) < count || page[len(page)-1].ID <= boundary {
+			break
+		}
+	}
+	e.submissions[handle] = seen
+
+	return nil
+}
+
+func isPending(s codeforces.Submission) bool {
+	return s.Verdict == "" || s.Verdict == "TESTING"
+}
+
+func (e *exporter) refreshContests() error {
+	var contests []codeforces.Contest
+	err := e.retry("contest.list", func() (err error) {
+		contests, err = e.client.GetContestList(false)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	upcoming := make(map[string]float64)
+	for _, c := range contests {
+		if c.Phase == "BEFORE" {
+			upcoming[formatLabels([]string{"contest_id", strconv.Itoa(c.ID), "name", c.Name})] = float64(c.StartTimeSeconds)
+		}
+	}
+
+	e.reg.replace("codeforces_contest_upcoming_start_seconds", upcoming)
+	return nil
+}
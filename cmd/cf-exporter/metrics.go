@@ -0,0 +1,151 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/mukundan314/go-codeforces"
+)
+
+// family is a metric family: a metric name with its samples, keyed by their
+// rendered labels.
+type family struct {
+	help    string
+	typ     string
+	samples map[string]float64
+}
+
+// registry holds the metrics of the exporter and serves them in the
+// Prometheus text format.
+type registry struct {
+	mu       sync.Mutex
+	families map[string]*family
+}
+
+func newRegistry() *registry {
+	return &registry{families: make(map[string]*family)}
+}
+
+// register declares a metric family. typ is "gauge", "counter" or "summary".
+func (r *registry) register(name, typ, help string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.families[name] = &family{help: help, typ: typ, samples: make(map[string]float64)}
+}
+
+// set sets the value of a sample.
+func (r *registry) set(name string, labels []string, v float64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.families[name].samples[formatLabels(labels)] = v
+}
+
+// add adds v to the value of a sample.
+func (r *registry) add(name string, labels []string, v float64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.families[name].samples[formatLabels(labels)] += v
+}
+
+// replace replaces every sample of a family, keyed by their labels rendered
+// with formatLabels.
+func (r *registry) replace(name string, samples map[string]float64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.families[name].samples = samples
+}
+
+// observe records an observation of a summary.
+func (r *registry) observe(name string, labels []string, v float64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := formatLabels(labels)
+	r.families[name].samples["_sum"+key] += v
+	r.families[name].samples["_count"+key]++
+}
+
+// formatLabels renders label name/value pairs as {name="value",...}.
+func formatLabels(labels []string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteByte('{')
+	for i := 0; i+1 < len(labels); i += 2 {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(labels[i])
+		b.WriteString(`="`)
+		b.WriteString(labelEscaper.Replace(labels[i+1]))
+		b.WriteByte('"')
+	}
+	b.WriteByte('}')
+	return b.String()
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// writeTo writes the metrics in the Prometheus text exposition format.
+func (r *registry) writeTo(w io.Writer) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	names := make([]string, 0, len(r.families))
+	for name := range r.families {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		f := r.families[name]
+		fmt.Fprintf(w, "# HELP %s %s\n", name, f.help)
+		fmt.Fprintf(w, "# TYPE %s %s\n", name, f.typ)
+
+		keys := make([]string, 0, len(f.samples))
+		for key := range f.samples {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			if _, err := fmt.Fprintf(w, "%s%s %s\n", name, key, strconv.FormatFloat(f.samples[key], 'g', -1, 64)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (r *registry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	r.writeTo(w)
+}
+
+// newMetricsHook returns a hook recording the API calls of a client by method
+// and API status: OK, FAILED, or error when no API response was read.
+func newMetricsHook(reg *registry) codeforces.Hook {
+	return codeforces.HookFuncs{
+		After: func(call *codeforces.APICall) {
+			status := call.Status
+			if status == "" {
+				status = "error"
+			}
+
+			reg.add("codeforces_api_calls_total", []string{"method", call.Method, "status", status}, 1)
+			reg.observe("codeforces_api_call_duration_seconds", []string{"method", call.Method}, call.Duration.Seconds())
+		},
+	}
+}
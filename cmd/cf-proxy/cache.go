@@ -0,0 +1,94 @@
+package main
+
+import (
+	"encoding/json"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// cache caches successful results for a fixed time and coalesces identical
+// calls in flight.
+type cache struct {
+	ttl time.Duration
+
+	mu       sync.Mutex
+	entries  map[string]cacheEntry
+	inflight map[string]*call
+}
+
+type cacheEntry struct {
+	result  json.RawMessage
+	expires time.Time
+}
+
+// call is a call in flight. done is closed once result and err are set.
+type call struct {
+	done   chan struct{}
+	result json.RawMessage
+	err    error
+}
+
+func newCache(ttl time.Duration) *cache {
+	return &cache{
+		ttl:      ttl,
+		entries:  make(map[string]cacheEntry),
+		inflight: make(map[string]*call),
+	}
+}
+
+// cacheKey returns the key of a call. url.Values.Encode sorts the parameters,
+// so the order of the query string does not matter.
+func cacheKey(method string, params map[string][]string) string {
+	return method + "?" + url.Values(params).Encode()
+}
+
+// get returns the cached result of a call, or waits for the identical call in
+// flight, or makes the call with fetch. Failed calls are not cached.
+func (c *cache) get(method string, params map[string][]string, fetch func() (json.RawMessage, error)) (json.RawMessage, error) {
+	key := cacheKey(method, params)
+
+	c.mu.Lock()
+	if e, ok := c.entries[key]; ok && time.Now().Before(e.expires) {
+		c.mu.Unlock()
+		return e.result, nil
+	}
+	if cl, ok := c.inflight[key]; ok {
+		c.mu.Unlock()
+		<-cl.done
+		return cl.result, cl.err
+	}
+
+	cl := &call{done: make(chan struct{})}
+	c.inflight[key] = cl
+	c.mu.Unlock()
+
+	cl.result, cl.err = fetch()
+
+	c.mu.Lock()
+	delete(c.inflight, key)
+	if cl.err == nil && c.ttl > 0 {
+		c.entries[key] = cacheEntry{result: cl.result, expires: time.Now().Add(c.ttl)}
+	}
+	c.mu.Unlock()
+
+	close(cl.done)
+	return cl.result, cl.err
+}
+
+// expireEvery removes expired entries every interval. It never returns.
+func (c *cache) expireEvery(interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	for now := range time.Tick(interval) {
+		c.mu.Lock()
+		for key, e := range c.entries {
+			if now.After(e.expires) {
+				delete(c.entries, key)
+			}
+		}
+		c.mu.Unlock()
+	}
+}
@@ -0,0 +1,183 @@
+// Command cf-proxy is a caching proxy for the Codeforces API.
+//
+// Usage:
+//
+//	cf-proxy [--addr host:port] [--rate d] [--ttl d] [--sign methods]
+//
+// cf-proxy serves the /api/<method> endpoints of Codeforces with the same
+// {status, comment, result} envelope. Calls are forwarded upstream through a
+// single rate-limited client, successful results are cached for --ttl and
+// identical calls in flight at the same time are sent upstream once. Point
+// clients at it with Client.SetBaseURL("http://host:port/api/").
+//
+// Signatures sent by clients are dropped and callers are not authenticated, so
+// calls are made anonymously by default. When CF_API_KEY and CF_API_SECRET are
+// set, the methods listed in --sign, and only those, are signed with them.
+// Their results are cached and served to every caller, so list only methods
+// whose authorized results may be shared with anyone able to reach the proxy;
+// methods such as user.friends return the data of the key owner.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mukundan314/go-codeforces"
+)
+
+func main() {
+	addr := flag.String("addr", "localhost:8080", "address to listen on")
+	rate := flag.Duration("rate", 2*time.Second, "minimum interval between upstream calls")
+	ttl := flag.Duration("ttl", time.Minute, "how long successful results are cached")
+	upstream := flag.String("upstream", "", "base URL of the upstream API, empty for Codeforces")
+	sign := flag.String("sign", "", "comma-separated methods signed with CF_API_KEY and CF_API_SECRET")
+	flag.Parse()
+
+	// Both clients share one rate limit, enforced by their transport.
+	httpClient := &http.Client{Transport: newThrottle(*rate, http.DefaultTransport)}
+	newClient := func() *codeforces.Client {
+		c := codeforces.NewClient()
+		c.SetHTTPClient(httpClient)
+		if *upstream != "" {
+			c.SetBaseURL(*upstream)
+		}
+		return c
+	}
+
+	p := newProxy(newClient(), *ttl)
+	if *sign != "" {
+		key, secret := os.Getenv("CF_API_KEY"), os.Getenv("CF_API_SECRET")
+		if key == "" || secret == "" {
+			log.Fatal("--sign requires CF_API_KEY and CF_API_SECRET")
+		}
+
+		p.signedClient = newClient()
+		p.signedClient.SetAPIKey(key, secret)
+		for _, method := range strings.Split(*sign, ",") {
+			if method = strings.TrimSpace(method); method != "" {
+				p.signed[method] = true
+			}
+		}
+	}
+	go p.cache.expireEvery(*ttl)
+
+	log.Printf("cf-proxy listening on %s", *addr)
+	log.Fatal(http.ListenAndServe(*addr, p))
+}
+
+// envelope is the response format of the Codeforces API.
+type envelope struct {
+	Status  string          `json:"status"`
+	Comment string          `json:"comment,omitempty"`
+	Result  json.RawMessage `json:"result,omitempty"`
+}
+
+// proxy is the HTTP handler of cf-proxy.
+type proxy struct {
+	client *codeforces.Client
+	cache  *cache
+
+	// signedClient makes the calls of the methods in signed.
+	signedClient *codeforces.Client
+	signed       map[string]bool
+}
+
+func newProxy(client *codeforces.Client, ttl time.Duration) *proxy {
+	return &proxy{client: client, cache: newCache(ttl), signed: make(map[string]bool)}
+}
+
+// clientFor returns the client calls of method are made with.
+func (p *proxy) clientFor(method string) *codeforces.Client {
+	if p.signed[method] {
+		return p.signedClient
+	}
+	return p.client
+}
+
+func (p *proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.URL.Path, "/api/") {
+		http.NotFound(w, r)
+		return
+	}
+
+	method := strings.TrimPrefix(r.URL.Path, "/api/")
+	if method == "" || strings.Contains(method, "/") {
+		writeEnvelope(w, http.StatusBadRequest, envelope{Status: "FAILED", Comment: "method: Unknown method"})
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		writeEnvelope(w, http.StatusBadRequest, envelope{Status: "FAILED", Comment: err.Error()})
+		return
+	}
+
+	params := make(map[string][]string)
+	for k, v := range r.Form {
+		switch k {
+		case "apiKey", "apiSig", "time":
+			continue
+		}
+		params[k] = v
+	}
+
+	result, err := p.cache.get(method, params, func() (json.RawMessage, error) {
+		var res json.RawMessage
+		err := p.clientFor(method).Call(method, params, &res)
+		return res, err
+	})
+
+	var apiErr *codeforces.APIError
+	switch {
+	case err == nil:
+		writeEnvelope(w, http.StatusOK, envelope{Status: "OK", Result: result})
+	case errors.As(err, &apiErr):
+		writeEnvelope(w, apiErr.StatusCode, envelope{Status: "FAILED", Comment: apiErr.Comment})
+	default:
+		log.Printf("%s: %v", method, err)
+		writeEnvelope(w, http.StatusBadGateway, envelope{Status: "FAILED", Comment: err.Error()})
+	}
+}
+
+// throttle is an http.RoundTripper sending at most one request per interval.
+type throttle struct {
+	interval  time.Duration
+	transport http.RoundTripper
+
+	mu   sync.Mutex
+	next time.Time
+}
+
+func newThrottle(interval time.Duration, transport http.RoundTripper) *throttle {
+	return &throttle{interval: interval, transport: transport}
+}
+
+func (t *throttle) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	now := time.Now()
+	at := t.next
+	if at.Before(now) {
+		at = now
+	}
+	t.next = at.Add(t.interval)
+	t.mu.Unlock()
+
+	select {
+	case <-time.After(at.Sub(now)):
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	}
+	return t.transport.RoundTrip(req)
+}
+
+func writeEnvelope(w http.ResponseWriter, status int, e envelope) {
+	w.Header().Set("Content-Type", "application/json;charset=UTF-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(e)
+}
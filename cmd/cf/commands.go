@@ -0,0 +1,383 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mukundan314/go-codeforces"
+)
+
+// command is a cf subcommand. args describes its arguments in the usage
+// message. Groups made of a single command register it under the empty name.
+type command struct {
+	args string
+	run  func(client *codeforces.Client, name string, args []string) error
+}
+
+var commands = map[string]map[string]command{
+	"user": {
+		"info":         {"<handle>...", userInfo},
+		"status":       {"[--from n] [--count n] <handle>", userStatus},
+		"rating":       {"<handle>", userRating},
+		"rated-list":   {"[--active]", userRatedList},
+		"friends":      {"[--online]", userFriends},
+		"blog-entries": {"<handle>", userBlogEntries},
+	},
+	"contest": {
+		"list":           {"[--gym]", contestList},
+		"standings":      {"[--from n] [--count n] [--handles h1,h2] [--room n] [--unofficial] [--watch] [--interval d] <contestId>", contestStandings},
+		"status":         {"[--handle h] [--from n] [--count n] <contestId>", contestStatus},
+		"hacks":          {"<contestId>", contestHacks},
+		"rating-changes": {"<contestId>", contestRatingChanges},
+	},
+	"problemset": {
+		"problems": {"[--tags t1,t2] [--problemset name]", problemsetProblems},
+		"recent":   {"[--count n] [--problemset name]", problemsetRecent},
+	},
+	"blog": {
+		"view":     {"<blogEntryId>", blogView},
+		"comments": {"<blogEntryId>", blogComments},
+	},
+	"standings": {
+		"": {"[--handles h1,h2] [--watch] [--interval d] <contestId>", contestStandings},
+	},
+	"recent": {
+		"": {"[--count n]", recentActions},
+	},
+}
+
+// parse parses the flags of a command and checks the number of positional
+// arguments, -1 meaning at least one.
+func parse(fs *flag.FlagSet, args []string, nargs int) ([]string, error) {
+	if err := fs.Parse(args); err != nil {
+		return nil, errUsage
+	}
+
+	rest := fs.Args()
+	if (nargs < 0 && len(rest) == 0) || (nargs >= 0 && len(rest) != nargs) {
+		fs.Usage()
+		return nil, errUsage
+	}
+
+	return rest, nil
+}
+
+func parseID(s string) (int, error) {
+	id, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, usageError(fmt.Sprintf("invalid id %q", s))
+	}
+	return id, nil
+}
+
+func splitList(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ",")
+}
+
+func userInfo(client *codeforces.Client, name string, args []string) error {
+	var out output
+	fs := newFlagSet(name, &out)
+	handles, err := parse(fs, args, -1)
+	if err != nil {
+		return err
+	}
+
+	users, err := client.GetUserInfo(handles)
+	if err != nil {
+		return err
+	}
+	return out.write(users, userTable(users))
+}
+
+func userStatus(client *codeforces.Client, name string, args []string) error {
+	var out output
+	fs := newFlagSet(name, &out)
+	from := fs.Int("from", 1, "index of the first submission")
+	count := fs.Int("count", 0, "number of submissions, 0 for all")
+	rest, err := parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	submissions, err := client.GetUserStatus(rest[0], *from, *count)
+	if err != nil {
+		return err
+	}
+	return out.write(submissions, submissionTable(submissions))
+}
+
+func userRating(client *codeforces.Client, name string, args []string) error {
+	var out output
+	fs := newFlagSet(name, &out)
+	rest, err := parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	changes, err := client.GetUserRating(rest[0])
+	if err != nil {
+		return err
+	}
+	return out.write(changes, ratingChangeTable(changes))
+}
+
+func userRatedList(client *codeforces.Client, name string, args []string) error {
+	var out output
+	fs := newFlagSet(name, &out)
+	active := fs.Bool("active", false, "only users active during the last month")
+	if _, err := parse(fs, args, 0); err != nil {
+		return err
+	}
+
+	users, err := client.GetUserRatedList(*active)
+	if err != nil {
+		return err
+	}
+	return out.write(users, userTable(users))
+}
+
+func userFriends(client *codeforces.Client, name string, args []string) error {
+	var out output
+	fs := newFlagSet(name, &out)
+	online := fs.Bool("online", false, "only online friends")
+	if _, err := parse(fs, args, 0); err != nil {
+		return err
+	}
+
+	handles, err := client.GetUserFriends(*online)
+	if err != nil {
+		return err
+	}
+	return out.write(handles, handleTable(handles))
+}
+
+func userBlogEntries(client *codeforces.Client, name string, args []string) error {
+	var out output
+	fs := newFlagSet(name, &out)
+	rest, err := parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	entries, err := client.GetUserBlogEntries(rest[0])
+	if err != nil {
+		return err
+	}
+	return out.write(entries, blogEntryTable(entries))
+}
+
+func contestList(client *codeforces.Client, name string, args []string) error {
+	var out output
+	fs := newFlagSet(name, &out)
+	gym := fs.Bool("gym", false, "list gym contests")
+	if _, err := parse(fs, args, 0); err != nil {
+		return err
+	}
+
+	contests, err := client.GetContestList(*gym)
+	if err != nil {
+		return err
+	}
+	return out.write(contests, contestTable(contests))
+}
+
+func contestStandings(client *codeforces.Client, name string, args []string) error {
+	var out output
+	fs := newFlagSet(name, &out)
+	from := fs.Int("from", 1, "index of the first row")
+	count := fs.Int("count", 0, "number of rows, 0 for all")
+	handles := fs.String("handles", "", "comma separated handles to show")
+	room := fs.Int("room", 0, "room to show, 0 for all")
+	unofficial := fs.Bool("unofficial", false, "show unofficial participants")
+	watch := fs.Bool("watch", false, "refresh the standings in the terminal until interrupted")
+	interval := fs.Duration("interval", 30*time.Second, "refresh interval with --watch")
+	rest, err := parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	contestID, err := parseID(rest[0])
+	if err != nil {
+		return err
+	}
+
+	if *watch {
+		if *interval <= 0 {
+			return usageError(fmt.Sprintf("invalid interval %v", *interval))
+		}
+		return watchStandings(client, contestID, *from, *count, splitList(*handles), *room, *unofficial, *interval)
+	}
+
+	contest, problems, rows, err := client.GetContestStandings(contestID, *from, *count, splitList(*handles), *room, *unofficial)
+	if err != nil {
+		return err
+	}
+
+	v := struct {
+		Contest  codeforces.Contest       `json:"contest"`
+		Problems []codeforces.Problem     `json:"problems"`
+		Rows     []codeforces.RanklistRow `json:"rows"`
+	}{contest, problems, rows}
+	return out.write(v, standingsTable(contest, problems, rows))
+}
+
+func contestStatus(client *codeforces.Client, name string, args []string) error {
+	var out output
+	fs := newFlagSet(name, &out)
+	handle := fs.String("handle", "", "only submissions of handle")
+	from := fs.Int("from", 1, "index of the first submission")
+	count := fs.Int("count", 0, "number of submissions, 0 for all")
+	rest, err := parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	contestID, err := parseID(rest[0])
+	if err != nil {
+		return err
+	}
+
+	submissions, err := client.GetContestStatus(contestID, *handle, *from, *count)
+	if err != nil {
+		return err
+	}
+	return out.write(submissions, submissionTable(submissions))
+}
+
+func contestHacks(client *codeforces.Client, name string, args []string) error {
+	var out output
+	fs := newFlagSet(name, &out)
+	rest, err := parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	contestID, err := parseID(rest[0])
+	if err != nil {
+		return err
+	}
+
+	hacks, err := client.GetContestHacks(contestID)
+	if err != nil {
+		return err
+	}
+	return out.write(hacks, hackTable(hacks))
+}
+
+func contestRatingChanges(client *codeforces.Client, name string, args []string) error {
+	var out output
+	fs := newFlagSet(name, &out)
+	rest, err := parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	contestID, err := parseID(rest[0])
+	if err != nil {
+		return err
+	}
+
+	changes, err := client.GetContestRatingChanges(contestID)
+	if err != nil {
+		return err
+	}
+	return out.write(changes, ratingChangeTable(changes))
+}
+
+func problemsetProblems(client *codeforces.Client, name string, args []string) error {
+	var out output
+	fs := newFlagSet(name, &out)
+	tags := fs.String("tags", "", "comma separated tags")
+	problemset := fs.String("problemset", "", "problemset name, empty for the default problemset")
+	if _, err := parse(fs, args, 0); err != nil {
+		return err
+	}
+
+	problems, stats, err := client.GetProblemsetProblems(splitList(*tags), *problemset)
+	if err != nil {
+		return err
+	}
+
+	v := struct {
+		Problems          []codeforces.Problem           `json:"problems"`
+		ProblemStatistics []codeforces.ProblemStatistics `json:"problemStatistics"`
+	}{problems, stats}
+	return out.write(v, problemTable(problems, stats))
+}
+
+func problemsetRecent(client *codeforces.Client, name string, args []string) error {
+	var out output
+	fs := newFlagSet(name, &out)
+	count := fs.Int("count", 100, "number of submissions")
+	problemset := fs.String("problemset", "", "problemset name, empty for the default problemset")
+	if _, err := parse(fs, args, 0); err != nil {
+		return err
+	}
+
+	submissions, err := client.GetProblemsetRecentStatus(*count, *problemset)
+	if err != nil {
+		return err
+	}
+	return out.write(submissions, submissionTable(submissions))
+}
+
+func blogView(client *codeforces.Client, name string, args []string) error {
+	var out output
+	fs := newFlagSet(name, &out)
+	rest, err := parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	blogEntryID, err := parseID(rest[0])
+	if err != nil {
+		return err
+	}
+
+	entry, err := client.GetBlogEntry(blogEntryID)
+	if err != nil {
+		return err
+	}
+	return out.write(entry, blogEntryTable([]codeforces.BlogEntry{entry}))
+}
+
+func blogComments(client *codeforces.Client, name string, args []string) error {
+	var out output
+	fs := newFlagSet(name, &out)
+	rest, err := parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	blogEntryID, err := parseID(rest[0])
+	if err != nil {
+		return err
+	}
+
+	comments, err := client.GetBlogEntryComments(blogEntryID)
+	if err != nil {
+		return err
+	}
+	return out.write(comments, commentTable(comments))
+}
+
+func recentActions(client *codeforces.Client, name string, args []string) error {
+	var out output
+	fs := newFlagSet(name, &out)
+	count := fs.Int("count", 30, "maximum number of actions, at most 100")
+	if _, err := parse(fs, args, 0); err != nil {
+		return err
+	}
+
+	actions, err := client.GetRecentActions(*count)
+	if err != nil {
+		return err
+	}
+	return out.write(actions, recentActionTable(actions))
+}
@@ -0,0 +1,205 @@
+// Command cf is a command-line client for the Codeforces API.
+//
+// Usage:
+//
+//	cf <group> <command> [flags] [args]
+//
+// Every command accepts --json and --csv to change the output format from the
+// default table. Credentials are read from the CF_API_KEY, CF_API_SECRET and
+// CF_LOCALE environment variables, falling back to the api_key, api_secret and
+// locale entries of $XDG_CONFIG_HOME/cf/config.
+//
+// Exit codes: 0 on success, 1 on other errors, 2 on usage errors, 3 when the
+// API call failed, 4 when the requested object was not found and 5 when the
+// call limit was exceeded.
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/mukundan314/go-codeforces"
+)
+
+// Exit codes of cf.
+const (
+	exitOK = iota
+	exitError
+	exitUsage
+	exitAPIError
+	exitNotFound
+	exitRateLimited
+)
+
+// errUsage is returned by commands called with invalid arguments, once their
+// usage has been printed.
+var errUsage = errors.New("invalid usage")
+
+// usageError is an invalid argument of a command, reported with exitUsage.
+type usageError string
+
+func (e usageError) Error() string {
+	return string(e)
+}
+
+func main() {
+	os.Exit(run(os.Args[1:]))
+}
+
+func run(args []string) int {
+	if len(args) < 1 {
+		usage()
+		return exitUsage
+	}
+
+	group, ok := commands[args[0]]
+	if !ok {
+		usage()
+		return exitUsage
+	}
+
+	name := args[0]
+	cmd, ok := group[""]
+	if ok {
+		args = args[1:]
+	} else {
+		if len(args) < 2 {
+			usage()
+			return exitUsage
+		}
+
+		cmd, ok = group[args[1]]
+		if !ok {
+			usage()
+			return exitUsage
+		}
+
+		name += " " + args[1]
+		args = args[2:]
+	}
+
+	client, err := newClient()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "cf:", err)
+		return exitError
+	}
+
+	if err := cmd.run(client, name, args); err != nil {
+		if err != errUsage {
+			fmt.Fprintln(os.Stderr, "cf:", err)
+		}
+		return exitCode(err)
+	}
+
+	return exitOK
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: cf <group> <command> [flags] [args]")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "commands:")
+
+	var lines []string
+	for groupName, group := range commands {
+		for cmdName, cmd := range group {
+			lines = append(lines, strings.Join(strings.Fields(fmt.Sprintf("cf %s %s %s", groupName, cmdName, cmd.args)), " "))
+		}
+	}
+	sort.Strings(lines)
+
+	for _, line := range lines {
+		fmt.Fprintln(os.Stderr, "  "+line)
+	}
+}
+
+// exitCode maps an error to the exit code of cf.
+func exitCode(err error) int {
+	var usageErr usageError
+	if err == errUsage || errors.As(err, &usageErr) {
+		return exitUsage
+	}
+
+	var apiErr *codeforces.APIError
+	if !errors.As(err, &apiErr) {
+		return exitError
+	}
+
+	comment := strings.ToLower(apiErr.Comment)
+	switch {
+	case apiErr.StatusCode == 429 || strings.Contains(comment, "call limit exceeded"):
+		return exitRateLimited
+	case strings.Contains(comment, "not found"):
+		return exitNotFound
+	default:
+		return exitAPIError
+	}
+}
+
+// newClient creates a client configured from the environment and the config
+// file.
+func newClient() (*codeforces.Client, error) {
+	config, err := readConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	for key, env := range map[string]string{
+		"api_key":    "CF_API_KEY",
+		"api_secret": "CF_API_SECRET",
+		"locale":     "CF_LOCALE",
+	} {
+		if v, ok := os.LookupEnv(env); ok {
+			config[key] = v
+		}
+	}
+
+	client := codeforces.NewClient()
+	if config["api_key"] != "" && config["api_secret"] != "" {
+		client.SetAPIKey(config["api_key"], config["api_secret"])
+	}
+	if config["locale"] != "" {
+		client.SetLocale(config["locale"])
+	}
+
+	return client, nil
+}
+
+// readConfig reads the "key = value" lines of the config file. A missing config
+// file is not an error.
+func readConfig() (map[string]string, error) {
+	config := make(map[string]string)
+
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return config, nil
+	}
+
+	f, err := os.Open(filepath.Join(dir, "cf", "config"))
+	if os.IsNotExist(err) {
+		return config, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		i := strings.Index(line, "=")
+		if i < 0 {
+			continue
+		}
+		config[strings.TrimSpace(line[:i])] = strings.TrimSpace(line[i+1:])
+	}
+
+	return config, scanner.Err()
+}
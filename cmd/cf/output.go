@@ -0,0 +1,229 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/mukundan314/go-codeforces"
+)
+
+// output holds the output format flags shared by every command.
+type output struct {
+	json bool
+	csv  bool
+	w    io.Writer
+}
+
+// newFlagSet creates the flag set of a command, with the output format flags
+// registered.
+func newFlagSet(name string, out *output) *flag.FlagSet {
+	fs := flag.NewFlagSet("cf "+name, flag.ContinueOnError)
+	fs.BoolVar(&out.json, "json", false, "output JSON")
+	fs.BoolVar(&out.csv, "csv", false, "output CSV")
+	out.w = os.Stdout
+	return fs
+}
+
+// table is the tabular form of a result, used by the table and CSV formats.
+type table struct {
+	header []string
+	rows   [][]string
+}
+
+func (t *table) add(row ...string) {
+	t.rows = append(t.rows, row)
+}
+
+// write writes a result in the selected format. v is written as JSON and t
+// as a table or CSV.
+func (o *output) write(v interface{}, t *table) error {
+	switch {
+	case o.json:
+		enc := json.NewEncoder(o.w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+
+	case o.csv:
+		cw := csv.NewWriter(o.w)
+		cw.Write(t.header)
+		cw.WriteAll(t.rows)
+		return cw.Error()
+
+	default:
+		tw := tabwriter.NewWriter(o.w, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, strings.Join(t.header, "\t"))
+		for _, row := range t.rows {
+			fmt.Fprintln(tw, strings.Join(row, "\t"))
+		}
+		return tw.Flush()
+	}
+}
+
+func itoa(i int) string {
+	return strconv.Itoa(i)
+}
+
+func ftoa(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+func formatTime(seconds int) string {
+	if seconds == 0 {
+		return ""
+	}
+	return time.Unix(int64(seconds), 0).UTC().Format("2006-01-02 15:04")
+}
+
+func formatDuration(seconds int) string {
+	return (time.Duration(seconds) * time.Second).String()
+}
+
+func partyName(party codeforces.Party) string {
+	if party.TeamName != "" {
+		return party.TeamName
+	}
+
+	handles := make([]string, len(party.Members))
+	for i, member := range party.Members {
+		handles[i] = member.Handle
+	}
+	return strings.Join(handles, ", ")
+}
+
+func userTable(users []codeforces.User) *table {
+	t := &table{header: []string{"handle", "rank", "rating", "max rating", "country", "organization"}}
+	for _, u := range users {
+		t.add(u.Handle, u.Rank, itoa(u.Rating), itoa(u.MaxRating), u.Country, u.Organization)
+	}
+	return t
+}
+
+func submissionTable(submissions []codeforces.Submission) *table {
+	t := &table{header: []string{"id", "time", "author", "problem", "name", "language", "verdict", "tests", "time (ms)", "memory (KB)"}}
+	for _, s := range submissions {
+		t.add(itoa(s.ID), formatTime(s.CreationTimeSeconds), partyName(s.Author), s.Problem.ProblemID().String(), s.Problem.Name,
+			s.ProgrammingLanguage, s.Verdict, itoa(s.PassedTestCount), itoa(s.TimeConsumedMillis), itoa(s.MemoryConsumedBytes/1024))
+	}
+	return t
+}
+
+func ratingChangeTable(changes []codeforces.RatingChange) *table {
+	t := &table{header: []string{"contest", "name", "handle", "rank", "old", "new", "delta", "time"}}
+	for _, c := range changes {
+		t.add(itoa(c.ContestID), c.ContestName, c.Handle, itoa(c.Rank), itoa(c.OldRating), itoa(c.NewRating),
+			fmt.Sprintf("%+d", c.NewRating-c.OldRating), formatTime(c.RatingUpdateTimeSeconds))
+	}
+	return t
+}
+
+func contestTable(contests []codeforces.Contest) *table {
+	t := &table{header: []string{"id", "name", "type", "phase", "start", "duration"}}
+	for _, c := range contests {
+		t.add(itoa(c.ID), c.Name, c.Type, c.Phase, formatTime(c.StartTimeSeconds), formatDuration(c.DurationSeconds))
+	}
+	return t
+}
+
+func standingsTable(contest codeforces.Contest, problems []codeforces.Problem, rows []codeforces.RanklistRow) *table {
+	t := &table{header: []string{"rank", "party", "points", "penalty", "hacks"}}
+	for _, p := range problems {
+		t.header = append(t.header, p.Index)
+	}
+
+	for _, row := range rows {
+		r := []string{itoa(row.Rank), partyName(row.Party), ftoa(row.Points), itoa(row.Penalty),
+			fmt.Sprintf("+%d:-%d", row.SuccessfulHackCount, row.UnsuccessfulHackCount)}
+		for _, result := range row.ProblemResults {
+			r = append(r, problemResultCell(result, contest.Type == "ICPC"))
+		}
+		t.add(r...)
+	}
+	return t
+}
+
+// problemResultCell formats a problem result like Codeforces standings: the
+// points, or "+" followed by rejected attempts in ICPC contests, when solved
+// and "-" followed by rejected attempts otherwise.
+func problemResultCell(result codeforces.ProblemResult, icpc bool) string {
+	switch {
+	case result.Points > 0 && icpc:
+		if result.RejectedAttemptCount > 0 {
+			return "+" + itoa(result.RejectedAttemptCount)
+		}
+		return "+"
+	case result.Points > 0:
+		return ftoa(result.Points)
+	case result.RejectedAttemptCount > 0:
+		return "-" + itoa(result.RejectedAttemptCount)
+	default:
+		return ""
+	}
+}
+
+func hackTable(hacks []codeforces.Hack) *table {
+	t := &table{header: []string{"id", "time", "hacker", "defender", "problem", "verdict"}}
+	for _, h := range hacks {
+		t.add(itoa(h.ID), formatTime(h.CreationTimeSeconds), partyName(h.Hacker), partyName(h.Defender),
+			h.Problem.ProblemID().String(), h.Verdict)
+	}
+	return t
+}
+
+func problemTable(problems []codeforces.Problem, stats []codeforces.ProblemStatistics) *table {
+	solved := make(map[codeforces.ProblemID]int, len(stats))
+	for _, s := range stats {
+		solved[codeforces.ProblemID{ContestID: s.ContestID, Index: s.Index}] = s.SolvedCount
+	}
+
+	t := &table{header: []string{"id", "name", "rating", "tags", "solved"}}
+	for _, p := range problems {
+		t.add(p.ProblemID().String(), p.Name, itoa(p.Rating), strings.Join(p.Tags, ","), itoa(solved[p.ProblemID()]))
+	}
+	return t
+}
+
+func blogEntryTable(entries []codeforces.BlogEntry) *table {
+	t := &table{header: []string{"id", "author", "title", "rating", "time"}}
+	for _, b := range entries {
+		t.add(itoa(b.ID), b.AuthorHandle, b.Title, itoa(b.Rating), formatTime(b.CreationTimeSeconds))
+	}
+	return t
+}
+
+func commentTable(comments []codeforces.Comment) *table {
+	t := &table{header: []string{"id", "author", "rating", "parent", "time"}}
+	for _, c := range comments {
+		t.add(itoa(c.ID), c.CommentatorHandle, itoa(c.Rating), itoa(c.ParentCommentID), formatTime(c.CreationTimeSeconds))
+	}
+	return t
+}
+
+func recentActionTable(actions []codeforces.RecentAction) *table {
+	t := &table{header: []string{"time", "blog entry", "title", "comment", "author"}}
+	for _, a := range actions {
+		author := a.BlogEntry.AuthorHandle
+		comment := ""
+		if a.Comment.ID != 0 {
+			author = a.Comment.CommentatorHandle
+			comment = itoa(a.Comment.ID)
+		}
+		t.add(formatTime(a.TimeSeconds), itoa(a.BlogEntry.ID), a.BlogEntry.Title, comment, author)
+	}
+	return t
+}
+
+func handleTable(handles []string) *table {
+	t := &table{header: []string{"handle"}}
+	for _, h := range handles {
+		t.add(h)
+	}
+	return t
+}
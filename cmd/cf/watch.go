@@ -0,0 +1,161 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/mukundan314/go-codeforces"
+)
+
+// ANSI escape codes used by the standings viewer.
+const (
+	ansiReset      = "\x1b[0m"
+	ansiBold       = "\x1b[1m"
+	ansiReverse    = "\x1b[7m"
+	ansiRed        = "\x1b[31m"
+	ansiGreen      = "\x1b[32m"
+	ansiFaint      = "\x1b[2m"
+	ansiClear      = "\x1b[H\x1b[2J"
+	ansiHideCursor = "\x1b[?25l"
+	ansiShowCursor = "\x1b[?25h"
+)
+
+// watchStandings polls the standings every interval and redraws them in the
+// terminal, highlighting rows that changed since the previous poll, until
+// interrupted.
+func watchStandings(client *codeforces.Client, contestID, from, count int, handles []string, room int, unofficial bool, interval time.Duration) error {
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	defer signal.Stop(interrupt)
+
+	fmt.Print(ansiHideCursor)
+	defer fmt.Print(ansiShowCursor)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var previous map[string]string
+	for {
+		contest, problems, rows, err := client.GetContestStandings(contestID, from, count, handles, room, unofficial)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "\rcf: %v", err)
+		} else {
+			current := make(map[string]string, len(rows))
+			for _, row := range rows {
+				current[rowKey(row)] = fmt.Sprint(row.Rank, row.Points, row.Penalty, row.ProblemResults)
+			}
+
+			w := bufio.NewWriter(os.Stdout)
+			renderStandings(w, contest, problems, rows, previous, current)
+			w.Flush()
+
+			previous = current
+		}
+
+		select {
+		case <-ticker.C:
+		case <-interrupt:
+			fmt.Println()
+			return nil
+		}
+	}
+}
+
+func rowKey(row codeforces.RanklistRow) string {
+	return row.Party.ParticipantType + "/" + partyName(row.Party)
+}
+
+// renderStandings draws the standings. Rows whose content differs between
+// previous and current are highlighted; nothing is highlighted on the first
+// draw, when previous is nil.
+func renderStandings(w io.Writer, contest codeforces.Contest, problems []codeforces.Problem, rows []codeforces.RanklistRow, previous, current map[string]string) {
+	icpc := contest.Type == "ICPC"
+
+	header := []string{"#", "party", "points", "penalty"}
+	for _, p := range problems {
+		header = append(header, p.Index)
+	}
+
+	type cell struct {
+		text  string
+		color string
+	}
+
+	lines := make([][]cell, len(rows))
+	widths := make([]int, len(header))
+	for i, h := range header {
+		widths[i] = utf8.RuneCountInString(h)
+	}
+
+	for i, row := range rows {
+		line := []cell{
+			{text: itoa(row.Rank)},
+			{text: partyName(row.Party)},
+			{text: ftoa(row.Points), color: ansiBold},
+			{text: itoa(row.Penalty)},
+		}
+
+		for _, result := range row.ProblemResults {
+			c := cell{text: problemResultCell(result, icpc)}
+			switch {
+			case result.Points > 0:
+				c.color = ansiGreen
+				c.text += " " + formatContestTime(result.BestSubmissionTimeSeconds)
+			case result.RejectedAttemptCount > 0:
+				c.color = ansiRed
+			}
+			line = append(line, c)
+		}
+
+		for j, c := range line {
+			if n := utf8.RuneCountInString(c.text); j < len(widths) && n > widths[j] {
+				widths[j] = n
+			}
+		}
+		lines[i] = line
+	}
+
+	fmt.Fprint(w, ansiClear)
+	fmt.Fprintf(w, "%s%s%s  %s%s%s\n\n", ansiBold, contest.Name, ansiReset, ansiFaint, time.Now().Format("15:04:05"), ansiReset)
+
+	for i, h := range header {
+		fmt.Fprint(w, ansiBold+pad(h, widths[i])+ansiReset+"  ")
+	}
+	fmt.Fprintln(w)
+
+	for i, line := range lines {
+		key := rowKey(rows[i])
+		changed := previous != nil && previous[key] != current[key]
+
+		for j, c := range line {
+			if j >= len(widths) {
+				break
+			}
+
+			text := pad(c.text, widths[j])
+			if changed {
+				text = ansiReverse + c.color + text + ansiReset
+			} else if c.color != "" {
+				text = c.color + text + ansiReset
+			}
+			fmt.Fprint(w, text+"  ")
+		}
+		fmt.Fprintln(w)
+	}
+}
+
+func pad(s string, width int) string {
+	return s + strings.Repeat(" ", width-utf8.RuneCountInString(s))
+}
+
+// formatContestTime formats a time relative to the start of a contest as
+// hours and minutes.
+func formatContestTime(seconds int) string {
+	return fmt.Sprintf("%d:%02d", seconds/3600, seconds/60%60)
+}
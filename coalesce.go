@@ -0,0 +1,73 @@
+package codeforces
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// inflightCall is an API call in flight shared by identical concurrent calls.
+// done is closed once result and err are set.
+type inflightCall struct {
+	done   chan struct{}
+	result json.RawMessage
+	err    error
+}
+
+// SetCoalescing enables or disables the coalescing of identical concurrent
+// calls. When enabled, which is the default, a call made while an identical
+// call, with the same method and parameters, is in flight waits for it and
+// shares its result instead of making another request.
+func (c *Client) SetCoalescing(enabled bool) {
+	c.inflightMu.Lock()
+	defer c.inflightMu.Unlock()
+
+	c.noCoalescing = !enabled
+}
+
+// coalesce calls fetch, unless an identical call is in flight, in which case
+// it waits for that call and returns its result. The key is computed before
+// fetch adds the locale and signature to params.
+func (c *Client) coalesce(method string, params map[string][]string, fetch func() (json.RawMessage, error)) (json.RawMessage, error) {
+	key := method + "?" + url.Values(params).Encode()
+
+	c.inflightMu.Lock()
+	if c.noCoalescing {
+		c.inflightMu.Unlock()
+		return fetch()
+	}
+	if call, ok := c.inflight[key]; ok {
+		c.inflightMu.Unlock()
+		<-call.done
+		return call.result, call.err
+	}
+
+	call := &inflightCall{done: make(chan struct{})}
+	if c.inflight == nil {
+		c.inflight = make(map[string]*inflightCall)
+	}
+	c.inflight[key] = call
+	c.inflightMu.Unlock()
+
+	// Release the waiting callers even if fetch panics, for example in a hook,
+	// then let the panic go on in this goroutine.
+	defer func() {
+		r := recover()
+		if r != nil {
+			call.result, call.err = nil, fmt.Errorf("codeforces: %s panicked: %v", method, r)
+		}
+
+		c.inflightMu.Lock()
+		delete(c.inflight, key)
+		c.inflightMu.Unlock()
+
+		close(call.done)
+
+		if r != nil {
+			panic(r)
+		}
+	}()
+
+	call.result, call.err = fetch()
+	return call.result, call.err
+}
@@ -0,0 +1,66 @@
+package codeforces
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCoalescePanic(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"status":"OK","result":[]}`)
+	}))
+	defer srv.Close()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	c := NewClient()
+	c.SetBaseURL(srv.URL + "/api/")
+	c.AddHook(HookFuncs{Before: func(call *APICall) {
+		close(started)
+		<-release
+		panic("hook failed")
+	}})
+
+	panicked := make(chan interface{})
+	go func() {
+		defer func() { panicked <- recover() }()
+
+		var res []Contest
+		c.Call("contest.list", nil, &res)
+	}()
+	<-started
+
+	waiterErr := make(chan error)
+	go func() {
+		var res []Contest
+		waiterErr <- c.Call("contest.list", nil, &res)
+	}()
+
+	// Give the second call time to join the call in flight.
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+
+	if r := <-panicked; r != "hook failed" {
+		t.Errorf("first call recovered %v, want the panic of the hook", r)
+	}
+
+	select {
+	case err := <-waiterErr:
+		if err == nil {
+			t.Error("coalesced call succeeded, want an error")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("coalesced call still blocked after the panic")
+	}
+
+	c.inflightMu.Lock()
+	n := len(c.inflight)
+	c.inflightMu.Unlock()
+	if n != 0 {
+		t.Errorf("%d calls left in flight, want 0", n)
+	}
+}
@@ -0,0 +1,386 @@
+package codeforces
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// CommentNode is a comment in a CommentTree.
+type CommentNode struct {
+	Comment Comment
+
+	// Parent is the comment replied to, or nil for top-level comments,
+	// orphans and comments detached to break a reply cycle.
+	Parent *CommentNode
+
+	// Children are the replies to the comment.
+	Children []*CommentNode
+
+	// Orphan is set for replies whose parent comment is not in the tree,
+	// typically because it was deleted. Orphans are top-level comments of the
+	// tree.
+	Orphan bool
+
+	tree *CommentTree
+}
+
+// CommentTree is the thread structure of the comments of a blog entry.
+type CommentTree struct {
+	// Roots are the top-level comments and the orphans.
+	Roots []*CommentNode
+
+	nodes map[int]*CommentNode
+}
+
+// NewCommentTree builds the tree of comments from their ParentCommentID.
+// Comments replying to a comment missing from comments become orphan roots.
+// Comments are sorted by time.
+func NewCommentTree(comments []Comment) *CommentTree {
+	t := &CommentTree{nodes: make(map[int]*CommentNode, len(comments))}
+	for _, c := range comments {
+		t.nodes[c.ID] = &CommentNode{Comment: c, tree: t}
+	}
+
+	for _, n := range t.nodes {
+		if n.Comment.ParentCommentID == 0 {
+			continue
+		}
+
+		parent, ok := t.nodes[n.Comment.ParentCommentID]
+		if !ok {
+			n.Orphan = true
+			continue
+		}
+		n.Parent = parent
+	}
+
+	t.breakCycles()
+
+	for _, n := range t.nodes {
+		if n.Parent == nil {
+			t.Roots = append(t.Roots, n)
+		} else {
+			n.Parent.Children = append(n.Parent.Children, n)
+		}
+	}
+
+	t.Sort(CommentsByTime)
+	return t
+}
+
+// breakCycles detaches one comment of every reply cycle, which can only come
+// from inconsistent data, making it a top-level comment. The detached comment
+// is the one with the lowest ID of the cycle; it is not an orphan since its
+// parent is in the tree.
+func (t *CommentTree) breakCycles() {
+	ids := make([]int, 0, len(t.nodes))
+	for id := range t.nodes {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	// Nodes are visited once: a walk up the ancestors stops at the first node
+	// visited by an earlier walk. Reaching a node of the current walk again
+	// means the walk entered a cycle.
+	visited := make(map[*CommentNode]bool, len(t.nodes))
+	for _, id := range ids {
+		walk := make(map[*CommentNode]bool)
+
+		n := t.nodes[id]
+		for n != nil && !visited[n] {
+			visited[n] = true
+			walk[n] = true
+			n = n.Parent
+		}
+		if n == nil || !walk[n] {
+			continue
+		}
+
+		first := n
+		for p := n.Parent; p != n; p = p.Parent {
+			if p.Comment.ID < first.Comment.ID {
+				first = p
+			}
+		}
+		first.Parent = nil
+	}
+}
+
+// GetBlogEntryCommentTree returns the tree of comments to the specified blog
+// entry.
+func (c *Client) GetBlogEntryCommentTree(blogEntryID int) (*CommentTree, error) {
+	comments, err := c.GetBlogEntryComments(blogEntryID)
+	if err != nil {
+		return nil, err
+	}
+	return NewCommentTree(comments), nil
+}
+
+// GetBlogEntryCommentTree returns the tree of comments to the specified blog
+// entry.
+//
+// GetBlogEntryCommentTree is a wrapper around
+// DefaultClient.GetBlogEntryCommentTree.
+func GetBlogEntryCommentTree(blogEntryID int) (*CommentTree, error) {
+	return DefaultClient.GetBlogEntryCommentTree(blogEntryID)
+}
+
+// Len returns the number of comments in the tree.
+func (t *CommentTree) Len() int {
+	return len(t.nodes)
+}
+
+// Node returns the node of the comment with the given ID, or nil if it is not
+// in the tree.
+func (t *CommentTree) Node(commentID int) *CommentNode {
+	return t.nodes[commentID]
+}
+
+// Orphans returns the orphan comments of the tree.
+func (t *CommentTree) Orphans() []*CommentNode {
+	var orphans []*CommentNode
+	for _, n := range t.Roots {
+		if n.Orphan {
+			orphans = append(orphans, n)
+		}
+	}
+	return orphans
+}
+
+// Depth returns the depth of the node, 0 for roots.
+func (n *CommentNode) Depth() int {
+	depth := 0
+	for p := n.Parent; p != nil; p = p.Parent {
+		depth++
+	}
+	return depth
+}
+
+// Root returns the root of the thread of the node.
+func (n *CommentNode) Root() *CommentNode {
+	for n.Parent != nil {
+		n = n.Parent
+	}
+	return n
+}
+
+// siblings returns the list the node belongs to.
+func (n *CommentNode) siblings() []*CommentNode {
+	if n.Parent != nil {
+		return n.Parent.Children
+	}
+	return n.tree.Roots
+}
+
+// NextSibling returns the next node with the same parent, or nil.
+func (n *CommentNode) NextSibling() *CommentNode {
+	siblings := n.siblings()
+	for i, s := range siblings {
+		if s == n && i+1 < len(siblings) {
+			return siblings[i+1]
+		}
+	}
+	return nil
+}
+
+// PrevSibling returns the previous node with the same parent, or nil.
+func (n *CommentNode) PrevSibling() *CommentNode {
+	siblings := n.siblings()
+	for i, s := range siblings {
+		if s == n && i > 0 {
+			return siblings[i-1]
+		}
+	}
+	return nil
+}
+
+// Size returns the number of comments in the subtree of the node, including
+// the node.
+func (n *CommentNode) Size() int {
+	size := 1
+	for _, c := range n.Children {
+		size += c.Size()
+	}
+	return size
+}
+
+// SubtreeRating returns the sum of the ratings of the comments in the subtree
+// of the node, including the node.
+func (n *CommentNode) SubtreeRating() int {
+	rating := n.Comment.Rating
+	for _, c := range n.Children {
+		rating += c.SubtreeRating()
+	}
+	return rating
+}
+
+// Walk calls f for the node and its descendants in depth-first pre-order. The
+// children of a node are skipped when f returns false for it.
+func (n *CommentNode) Walk(f func(n *CommentNode) bool) {
+	if !f(n) {
+		return
+	}
+	for _, c := range n.Children {
+		c.Walk(f)
+	}
+}
+
+// Walk calls f for every node of the tree in depth-first pre-order. The
+// children of a node are skipped when f returns false for it.
+func (t *CommentTree) Walk(f func(n *CommentNode) bool) {
+	for _, n := range t.Roots {
+		n.Walk(f)
+	}
+}
+
+// CommentIterator iterates over the nodes of a CommentTree.
+type CommentIterator struct {
+	queue []*CommentNode
+	bfs   bool
+}
+
+// DepthFirst returns an iterator over the nodes of the tree in depth-first
+// pre-order, the order in which threads are displayed.
+func (t *CommentTree) DepthFirst() *CommentIterator {
+	it := &CommentIterator{}
+	for i := len(t.Roots) - 1; i >= 0; i-- {
+		it.queue = append(it.queue, t.Roots[i])
+	}
+	return it
+}
+
+// BreadthFirst returns an iterator over the nodes of the tree by increasing
+// depth.
+func (t *CommentTree) BreadthFirst() *CommentIterator {
+	return &CommentIterator{queue: append([]*CommentNode(nil), t.Roots...), bfs: true}
+}
+
+// Next returns the next node, or nil once every node was returned.
+func (it *CommentIterator) Next() *CommentNode {
+	if len(it.queue) == 0 {
+		return nil
+	}
+
+	var n *CommentNode
+	if it.bfs {
+		n, it.queue = it.queue[0], it.queue[1:]
+		it.queue = append(it.queue, n.Children...)
+	} else {
+		n, it.queue = it.queue[len(it.queue)-1], it.queue[:len(it.queue)-1]
+		for i := len(n.Children) - 1; i >= 0; i-- {
+			it.queue = append(it.queue, n.Children[i])
+		}
+	}
+	return n
+}
+
+// CommentOrder is a sort order for comment trees.
+type CommentOrder int
+
+// Sort orders accepted by CommentTree.Sort.
+const (
+	CommentsByTime CommentOrder = iota
+	CommentsByTimeDesc
+	CommentsByRating
+	CommentsBySubtreeRating
+)
+
+// Sort sorts the roots of the tree and the children of every node. Rating
+// orders are decreasing; ties are broken by time.
+func (t *CommentTree) Sort(order CommentOrder) {
+	var sortNodes func(nodes []*CommentNode)
+	sortNodes = func(nodes []*CommentNode) {
+		keys := make(map[*CommentNode]int, len(nodes))
+		for _, n := range nodes {
+			switch order {
+			case CommentsByRating:
+				keys[n] = n.Comment.Rating
+			case CommentsBySubtreeRating:
+				keys[n] = n.SubtreeRating()
+			}
+		}
+
+		sort.Slice(nodes, func(i, j int) bool {
+			a, b := nodes[i], nodes[j]
+			if keys[a] != keys[b] {
+				return keys[a] > keys[b]
+			}
+
+			if a.Comment.CreationTimeSeconds != b.Comment.CreationTimeSeconds {
+				if order == CommentsByTimeDesc {
+					return a.Comment.CreationTimeSeconds > b.Comment.CreationTimeSeconds
+				}
+				return a.Comment.CreationTimeSeconds < b.Comment.CreationTimeSeconds
+			}
+			if order == CommentsByTimeDesc {
+				return a.Comment.ID > b.Comment.ID
+			}
+			return a.Comment.ID < b.Comment.ID
+		})
+
+		for _, n := range nodes {
+			sortNodes(n.Children)
+		}
+	}
+
+	sortNodes(t.Roots)
+}
+
+// commentHeader returns the header line of a comment: its author, rating and
+// time.
+func commentHeader(n *CommentNode) string {
+	header := fmt.Sprintf("%s (%+d, %s)", n.Comment.CommentatorHandle, n.Comment.Rating,
+		time.Unix(int64(n.Comment.CreationTimeSeconds), 0).UTC().Format("2006-01-02 15:04"))
+	if n.Orphan {
+		header += " in reply to a deleted comment"
+	}
+	return header
+}
+
+// indentLines prefixes every non-empty line of s with indent.
+func indentLines(s, indent string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = indent + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+var markdownEscaper = strings.NewReplacer(`\`, `\\`, "`", "\\`", "*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`)
+
+// WriteMarkdown writes the tree as nested Markdown lists, one item per
+// comment with its author, rating and time followed by its text converted to
+// Markdown.
+func (t *CommentTree) WriteMarkdown(w io.Writer) error {
+	it := t.DepthFirst()
+	for n := it.Next(); n != nil; n = it.Next() {
+		indent := strings.Repeat("  ", n.Depth())
+		body := indentLines(n.Comment.ParseContent().Markdown(), indent+"  ")
+
+		header := markdownEscaper.Replace(commentHeader(n))
+		if _, err := fmt.Fprintf(w, "%s- **%s**\n\n%s\n\n", indent, header, body); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteText writes the tree as plain text, each reply indented below the
+// comment it replies to.
+func (t *CommentTree) WriteText(w io.Writer) error {
+	it := t.DepthFirst()
+	for n := it.Next(); n != nil; n = it.Next() {
+		indent := strings.Repeat("    ", n.Depth())
+		body := indentLines(n.Comment.ParseContent().Text(), indent+"  ")
+
+		if _, err := fmt.Fprintf(w, "%s%s\n%s\n\n", indent, commentHeader(n), body); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package codeforces
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestNewCommentTree(t *testing.T) {
+	tests := []struct {
+		name     string
+		comments []Comment
+		// parents maps the ID of every comment to the ID of its parent in
+		// the tree, 0 for roots.
+		parents map[int]int
+		orphans []int
+	}{
+		{
+			name: "thread",
+			comments: []Comment{
+				{ID: 1},
+				{ID: 2, ParentCommentID: 1},
+				{ID: 3, ParentCommentID: 2},
+				{ID: 4, ParentCommentID: 1},
+			},
+			parents: map[int]int{1: 0, 2: 1, 3: 2, 4: 1},
+		},
+		{
+			name: "own parent",
+			comments: []Comment{
+				{ID: 1, ParentCommentID: 1},
+				{ID: 2, ParentCommentID: 1},
+			},
+			parents: map[int]int{1: 0, 2: 1},
+		},
+		{
+			// 1 replies to the cycle formed by 2 and 3 and has the lowest
+			// ID, yet the cycle is broken on 2.
+			name: "cycle with descendants",
+			comments: []Comment{
+				{ID: 1, ParentCommentID: 3},
+				{ID: 2, ParentCommentID: 3},
+				{ID: 3, ParentCommentID: 2},
+				{ID: 4, ParentCommentID: 1},
+				{ID: 5, ParentCommentID: 2},
+			},
+			parents: map[int]int{1: 3, 2: 0, 3: 2, 4: 1, 5: 2},
+		},
+		{
+			name: "deleted parent",
+			comments: []Comment{
+				{ID: 1},
+				{ID: 3, ParentCommentID: 2},
+				{ID: 4, ParentCommentID: 3},
+			},
+			parents: map[int]int{1: 0, 3: 0, 4: 3},
+			orphans: []int{3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tree := NewCommentTree(tt.comments)
+
+			for id, parentID := range tt.parents {
+				n := tree.Node(id)
+				got := 0
+				if n.Parent != nil {
+					got = n.Parent.Comment.ID
+				}
+				if got != parentID {
+					t.Errorf("parent of %d = %d, want %d", id, got, parentID)
+				}
+			}
+
+			size := 0
+			tree.Walk(func(n *CommentNode) bool {
+				size++
+				return true
+			})
+			if size != len(tt.comments) {
+				t.Errorf("walked %d comments, want %d", size, len(tt.comments))
+			}
+
+			var orphans []int
+			for _, n := range tree.Orphans() {
+				orphans = append(orphans, n.Comment.ID)
+			}
+			sort.Ints(orphans)
+			if fmt.Sprint(orphans) != fmt.Sprint(tt.orphans) {
+				t.Errorf("orphans = %v, want %v", orphans, tt.orphans)
+			}
+
+			var b strings.Builder
+			if err := tree.WriteText(&b); err != nil {
+				t.Fatal(err)
+			}
+			if got, want := strings.Count(b.String(), "deleted comment"), len(tt.orphans); got != want {
+				t.Errorf("%d comments rendered as replies to deleted comments, want %d:\n%s", got, want, b.String())
+			}
+		})
+	}
+}
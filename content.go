@@ -0,0 +1,837 @@
+package codeforces
+
+import (
+	"html"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Content is the parsed HTML of a blog entry or a comment.
+//
+// Codeforces content is HTML with LaTeX formulas between $$$ delimiters
+// ($$$$$$ for display formulas), spoilers, links to user profiles and code
+// blocks. Formulas are kept as is by every conversion.
+type Content struct {
+	root *htmlNode
+}
+
+// ParseContent parses the HTML of a blog entry or a comment.
+func ParseContent(s string) *Content {
+	return &Content{root: parseHTML(s)}
+}
+
+// ParseContent parses the content of the blog entry.
+func (b BlogEntry) ParseContent() *Content {
+	return ParseContent(b.Content)
+}
+
+// ParseContent parses the text of the comment.
+func (c Comment) ParseContent() *Content {
+	return ParseContent(c.Text)
+}
+
+// RefKind is the kind of a Ref.
+type RefKind int
+
+// Kinds of references.
+const (
+	RefUser RefKind = iota
+	RefProblem
+	RefContest
+	RefBlogEntry
+)
+
+func (k RefKind) String() string {
+	switch k {
+	case RefUser:
+		return "user"
+	case RefProblem:
+		return "problem"
+	case RefContest:
+		return "contest"
+	case RefBlogEntry:
+		return "blog entry"
+	default:
+		return "RefKind(" + strconv.Itoa(int(k)) + ")"
+	}
+}
+
+// Ref is a reference to a user, problem, contest or blog entry found in
+// content.
+type Ref struct {
+	Kind RefKind
+
+	// Handle is set for RefUser.
+	Handle string
+
+	// Problem is set for RefProblem.
+	Problem ProblemID
+
+	// ContestID is set for RefContest.
+	ContestID int
+
+	// BlogEntryID is set for RefBlogEntry.
+	BlogEntryID int
+
+	// URL is the absolute URL of the link the reference was found in, or
+	// empty for [user:handle] mentions.
+	URL string
+}
+
+var (
+	profilePathRegexp      = regexp.MustCompile(`^/profile/([\w.\-]+)/?$`)
+	problemPathRegexp      = regexp.MustCompile(`^/(contest|gym)/(\d+)/problem/(\w+)/?$`)
+	problemsetPathRegexp   = regexp.MustCompile(`^/problemset/(?:problem|gymProblem)/(\d+)/(\w+)/?$`)
+	problemsetsPathRegexp  = regexp.MustCompile(`^/problemsets/(\w+)/problem/\d+/(\w+)/?$`)
+	contestPathRegexp      = regexp.MustCompile(`^/(contest|gym)/(\d+)(/.*)?$`)
+	blogEntryPathRegexp    = regexp.MustCompile(`^/blog/entry/(\d+)/?$`)
+	userMentionRegexp      = regexp.MustCompile(`\[user:([\w.\-]+)(?:,[^\]]*)?\]`)
+	markdownLineStartRegex = regexp.MustCompile(`^(?:[#>+\-=]|\d+[.)])`)
+)
+
+// resolveURL resolves a link of the content against the Codeforces site and
+// returns it, or an empty string if its scheme is not http, https or mailto.
+func resolveURL(s string) string {
+	u, err := url.Parse(strings.TrimSpace(s))
+	if err != nil {
+		return ""
+	}
+
+	base, _ := url.Parse(siteURL + "/")
+	u = base.ResolveReference(u)
+
+	switch u.Scheme {
+	case "http", "https", "mailto":
+		return u.String()
+	default:
+		return ""
+	}
+}
+
+// isCodeforcesHost reports whether host is a domain of Codeforces.
+func isCodeforcesHost(host string) bool {
+	host = strings.ToLower(host)
+	for _, domain := range []string{"codeforces.com", "codeforces.ru", "codeforc.es"} {
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseRef returns the reference an absolute link points to, if any.
+func parseRef(link string) (Ref, bool) {
+	u, err := url.Parse(link)
+	if err != nil || !isCodeforcesHost(u.Host) {
+		return Ref{}, false
+	}
+
+	p := u.Path
+	if m := profilePathRegexp.FindStringSubmatch(p); m != nil {
+		return Ref{Kind: RefUser, Handle: m[1], URL: link}, true
+	}
+	if m := problemPathRegexp.FindStringSubmatch(p); m != nil {
+		contestID, _ := strconv.Atoi(m[2])
+		return Ref{Kind: RefProblem, Problem: ProblemID{ContestID: contestID, Index: strings.ToUpper(m[3])}, URL: link}, true
+	}
+	if m := problemsetPathRegexp.FindStringSubmatch(p); m != nil {
+		contestID, _ := strconv.Atoi(m[1])
+		return Ref{Kind: RefProblem, Problem: ProblemID{ContestID: contestID, Index: strings.ToUpper(m[2])}, URL: link}, true
+	}
+	if m := problemsetsPathRegexp.FindStringSubmatch(p); m != nil {
+		return Ref{Kind: RefProblem, Problem: ProblemID{ProblemsetName: m[1], Index: m[2]}, URL: link}, true
+	}
+	if m := contestPathRegexp.FindStringSubmatch(p); m != nil {
+		contestID, _ := strconv.Atoi(m[2])
+		return Ref{Kind: RefContest, ContestID: contestID, URL: link}, true
+	}
+	if m := blogEntryPathRegexp.FindStringSubmatch(p); m != nil {
+		id, _ := strconv.Atoi(m[1])
+		return Ref{Kind: RefBlogEntry, BlogEntryID: id, URL: link}, true
+	}
+
+	return Ref{}, false
+}
+
+// Refs returns the users, problems, contests and blog entries linked from the
+// content, and the users mentioned as [user:handle], in order of first
+// appearance and without duplicates.
+func (c *Content) Refs() []Ref {
+	var refs []Ref
+	seen := make(map[Ref]bool)
+	add := func(ref Ref) {
+		key := ref
+		key.URL = ""
+		if ref.Kind == RefUser {
+			key.Handle = strings.ToLower(ref.Handle)
+		}
+		if !seen[key] {
+			seen[key] = true
+			refs = append(refs, ref)
+		}
+	}
+
+	var walk func(n *htmlNode)
+	walk = func(n *htmlNode) {
+		switch n.tag {
+		case "":
+			for _, m := range userMentionRegexp.FindAllStringSubmatch(n.text, -1) {
+				add(Ref{Kind: RefUser, Handle: m[1]})
+			}
+		case "a":
+			if ref, ok := parseRef(resolveURL(n.attr("href"))); ok {
+				add(ref)
+			}
+		case "script", "style":
+			return
+		}
+
+		for _, child := range n.children {
+			walk(child)
+		}
+	}
+	walk(c.root)
+
+	return refs
+}
+
+// Markdown converts the content to Markdown. Spoilers become <details>
+// elements, code blocks become fenced code blocks and relative links are
+// made absolute.
+func (c *Content) Markdown() string {
+	r := &contentRenderer{markdown: true, lineStart: true}
+	r.renderChildren(c.root)
+	return r.String()
+}
+
+// Text converts the content to plain text.
+func (c *Content) Text() string {
+	r := &contentRenderer{lineStart: true}
+	r.renderChildren(c.root)
+	return r.String()
+}
+
+// contentRenderer renders content as Markdown or plain text.
+type contentRenderer struct {
+	markdown bool
+
+	b        strings.Builder
+	prefixes []string
+
+	// newlines is the number of line breaks to write before the next text,
+	// and breakPrefix the prefix of the blank lines among them.
+	newlines    int
+	breakPrefix string
+
+	// space is set when a space is to be written before the next word.
+	space bool
+
+	// noSpace is set after an opening markup, so that leading whitespace of
+	// its content is dropped.
+	noSpace bool
+
+	// lineStart is set at the start of a line, after its prefix.
+	lineStart bool
+
+	// inMath is set between $$$ delimiters.
+	inMath bool
+}
+
+func (r *contentRenderer) String() string {
+	return strings.TrimRight(r.b.String(), " \n")
+}
+
+func (r *contentRenderer) prefix() string {
+	return strings.Join(r.prefixes, "")
+}
+
+// breakLine requests n line breaks before the next text. It has no effect at
+// the start of a line.
+func (r *contentRenderer) breakLine(n int) {
+	if r.lineStart {
+		r.space = false
+		return
+	}
+
+	// Blank lines get the prefix of the outermost block being ended or
+	// started.
+	if prefix := r.prefix(); r.newlines == 0 || len(prefix) < len(r.breakPrefix) {
+		r.breakPrefix = prefix
+	}
+	if n > r.newlines {
+		r.newlines = n
+	}
+	r.space = false
+
+	// Formulas do not span blocks, so an unmatched $$$ ends with its block.
+	if n >= 2 {
+		r.inMath = false
+	}
+}
+
+// flush writes the pending line breaks.
+func (r *contentRenderer) flush() {
+	switch {
+	case r.newlines > 0:
+		for i := 0; i < r.newlines; i++ {
+			if i > 0 {
+				r.b.WriteString(strings.TrimRight(r.breakPrefix, " "))
+			}
+			r.b.WriteByte('\n')
+		}
+		r.b.WriteString(r.prefix())
+		r.lineStart = true
+		r.space = false
+	case r.b.Len() == 0:
+		r.b.WriteString(r.prefix())
+	}
+	r.newlines = 0
+}
+
+func (r *contentRenderer) writeSpace() {
+	if r.noSpace {
+		r.space = false
+		r.noSpace = false
+	}
+	if r.space && !r.lineStart {
+		r.b.WriteByte(' ')
+	}
+	r.space = false
+}
+
+// open writes an opening markup, after any pending space.
+func (r *contentRenderer) open(s string) {
+	r.flush()
+	r.writeSpace()
+	r.b.WriteString(s)
+	r.lineStart = false
+	r.noSpace = true
+}
+
+// close writes a closing markup, keeping any pending space after it.
+func (r *contentRenderer) close(s string) {
+	r.flush()
+	r.b.WriteString(s)
+	r.lineStart = false
+	r.noSpace = false
+}
+
+// word writes a word, escaped if escape is set.
+func (r *contentRenderer) word(s string, escape bool) {
+	r.flush()
+	r.writeSpace()
+	if escape {
+		s = r.escape(s)
+	}
+	r.b.WriteString(s)
+	r.lineStart = false
+}
+
+// text writes text, collapsing whitespace.
+func (r *contentRenderer) text(s string, escape bool) {
+	words := strings.Fields(s)
+	if len(words) == 0 {
+		if s != "" {
+			r.space = true
+		}
+		return
+	}
+
+	if isSpace(s[0]) {
+		r.space = true
+	}
+	for i, w := range words {
+		if i > 0 {
+			r.space = true
+		}
+		r.word(w, escape)
+	}
+	if isSpace(s[len(s)-1]) {
+		r.space = true
+	}
+}
+
+// line writes a line of its own.
+func (r *contentRenderer) line(s string) {
+	r.breakLine(2)
+	r.flush()
+	r.b.WriteString(s)
+	r.lineStart = false
+	r.breakLine(2)
+}
+
+// preformatted writes lines as is, each with the current prefix.
+func (r *contentRenderer) preformatted(lines []string) {
+	r.flush()
+	for i, line := range lines {
+		if i > 0 {
+			r.b.WriteByte('\n')
+			if line == "" {
+				r.b.WriteString(strings.TrimRight(r.prefix(), " "))
+			} else {
+				r.b.WriteString(r.prefix())
+			}
+		}
+		r.b.WriteString(line)
+	}
+	r.lineStart = false
+}
+
+// escape escapes the Markdown special characters of a word outside formulas.
+// Inside formulas, LaTeX is kept as is but <, > and & are still replaced by
+// entities so that no raw HTML gets through.
+func (r *contentRenderer) escape(s string) string {
+	if !r.markdown {
+		return s
+	}
+
+	var b strings.Builder
+	if r.lineStart && !r.inMath && markdownLineStartRegex.MatchString(s) {
+		b.WriteByte('\\')
+	}
+
+	for i := 0; i < len(s); {
+		if s[i] == '$' {
+			j := i
+			for j < len(s) && s[j] == '$' {
+				j++
+			}
+			if j-i >= 3 {
+				r.inMath = !r.inMath
+			}
+			b.WriteString(s[i:j])
+			i = j
+			continue
+		}
+
+		switch {
+		case r.inMath && (s[i] == '<' || s[i] == '>' || s[i] == '&'):
+			b.WriteString(html.EscapeString(s[i : i+1]))
+		case !r.inMath && strings.IndexByte("\\`*_[]<>|&", s[i]) >= 0:
+			b.WriteByte('\\')
+			b.WriteByte(s[i])
+		default:
+			b.WriteByte(s[i])
+		}
+		i++
+	}
+
+	return b.String()
+}
+
+func (r *contentRenderer) renderChildren(n *htmlNode) {
+	for _, child := range n.children {
+		r.render(child)
+	}
+}
+
+// inlineMarkup are the Markdown delimiters of inline elements.
+var inlineMarkup = map[string]string{
+	"b": "**", "strong": "**", "i": "*", "em": "*", "s": "~~", "strike": "~~", "del": "~~",
+}
+
+func (r *contentRenderer) render(n *htmlNode) {
+	switch n.tag {
+	case "":
+		r.text(n.text, true)
+
+	case "script", "style", "head", "title", "template":
+
+	case "br":
+		if r.markdown && !r.lineStart {
+			r.b.WriteString("  ")
+		}
+		r.breakLine(1)
+
+	case "hr":
+		if r.markdown {
+			r.line("---")
+		} else {
+			r.breakLine(2)
+		}
+
+	case "p", "table", "blockquote", "pre", "h1", "h2", "h3", "h4", "h5", "h6":
+		r.renderBlock(n)
+
+	case "ul", "ol":
+		r.renderList(n)
+
+	case "div":
+		if n.hasClass("spoiler") {
+			r.renderSpoiler(n)
+			return
+		}
+		r.breakLine(1)
+		r.renderChildren(n)
+		r.breakLine(1)
+
+	case "center", "li", "dt", "dd", "tr", "section", "article", "header", "footer", "figure", "details", "summary":
+		r.breakLine(1)
+		r.renderChildren(n)
+		r.breakLine(1)
+
+	case "a":
+		link := resolveURL(n.attr("href"))
+		if !r.markdown || link == "" || strings.TrimSpace(n.textContent()) == "" {
+			r.renderChildren(n)
+			return
+		}
+		r.open("[")
+		r.renderChildren(n)
+		r.close("](" + markdownURL(link) + ")")
+
+	case "img":
+		alt := n.attr("alt")
+		src := resolveURL(n.attr("src"))
+		switch {
+		case r.markdown && src != "":
+			r.word("!["+r.escape(alt)+"]("+markdownURL(src)+")", false)
+		case alt != "":
+			r.text(alt, true)
+		}
+
+	case "code", "tt", "kbd":
+		code := strings.Join(strings.Fields(n.textContent()), " ")
+		if code == "" {
+			return
+		}
+		if r.markdown {
+			fence := backtickFence(code, 1)
+			if strings.HasPrefix(code, "`") || strings.HasSuffix(code, "`") {
+				code = " " + code + " "
+			}
+			code = fence + code + fence
+		}
+		r.word(code, false)
+
+	default:
+		markup, ok := inlineMarkup[n.tag]
+		if !r.markdown || !ok || strings.TrimSpace(n.textContent()) == "" {
+			r.renderChildren(n)
+			return
+		}
+		r.open(markup)
+		r.renderChildren(n)
+		r.close(markup)
+	}
+}
+
+func (r *contentRenderer) renderBlock(n *htmlNode) {
+	r.breakLine(2)
+
+	switch n.tag {
+	case "pre":
+		code := strings.TrimRight(strings.TrimPrefix(n.textContent(), "\n"), "\n ")
+		lines := strings.Split(code, "\n")
+		if r.markdown {
+			fence := backtickFence(code, 3)
+			lines = append(append([]string{fence + preLanguage(n)}, lines...), fence)
+		}
+		r.preformatted(lines)
+
+	case "blockquote":
+		if r.markdown {
+			r.prefixes = append(r.prefixes, "> ")
+		} else {
+			r.prefixes = append(r.prefixes, "    ")
+		}
+		r.renderChildren(n)
+		r.prefixes = r.prefixes[:len(r.prefixes)-1]
+
+	case "table":
+		r.renderTable(n)
+
+	case "p":
+		r.renderChildren(n)
+
+	default:
+		if r.markdown {
+			level, _ := strconv.Atoi(n.tag[1:])
+			r.open(strings.Repeat("#", level) + " ")
+		}
+		r.renderChildren(n)
+	}
+
+	r.breakLine(2)
+}
+
+func (r *contentRenderer) renderList(n *htmlNode) {
+	nested := false
+	for p := n.parent; p != nil; p = p.parent {
+		if p.tag == "li" {
+			nested = true
+		}
+	}
+	if nested {
+		r.breakLine(1)
+	} else {
+		r.breakLine(2)
+	}
+
+	number := 1
+	if start, err := strconv.Atoi(n.attr("start")); err == nil {
+		number = start
+	}
+
+	for _, child := range n.children {
+		if child.tag != "li" {
+			if strings.TrimSpace(child.textContent()) != "" {
+				r.render(child)
+			}
+			continue
+		}
+
+		marker := "- "
+		if n.tag == "ol" {
+			marker = strconv.Itoa(number) + ". "
+			number++
+		}
+
+		r.breakLine(1)
+		r.flush()
+		r.b.WriteString(marker)
+		r.lineStart = true
+		r.prefixes = append(r.prefixes, strings.Repeat(" ", len(marker)))
+		r.renderChildren(child)
+		r.prefixes = r.prefixes[:len(r.prefixes)-1]
+		r.breakLine(1)
+	}
+
+	if nested {
+		r.breakLine(1)
+	} else {
+		r.breakLine(2)
+	}
+}
+
+func (r *contentRenderer) renderSpoiler(n *htmlNode) {
+	var title, content *htmlNode
+	var walk func(n *htmlNode)
+	walk = func(n *htmlNode) {
+		for _, child := range n.children {
+			switch {
+			case title == nil && child.hasClass("spoiler-title"):
+				title = child
+			case content == nil && child.hasClass("spoiler-content"):
+				content = child
+			default:
+				walk(child)
+			}
+		}
+	}
+	walk(n)
+
+	if content == nil {
+		content = n
+	}
+	titleText := ""
+	if title != nil {
+		titleText = strings.Join(strings.Fields(title.textContent()), " ")
+	}
+
+	if r.markdown {
+		r.line("<details><summary>" + html.EscapeString(titleText) + "</summary>")
+		r.renderChildren(content)
+		r.line("</details>")
+		return
+	}
+
+	r.breakLine(2)
+	if titleText != "" {
+		r.text(titleText, false)
+		r.breakLine(1)
+	}
+	r.renderChildren(content)
+	r.breakLine(2)
+}
+
+func (r *contentRenderer) renderTable(n *htmlNode) {
+	var rows [][]string
+	var walk func(n *htmlNode)
+	walk = func(n *htmlNode) {
+		for _, child := range n.children {
+			if child.tag != "tr" {
+				walk(child)
+				continue
+			}
+
+			var row []string
+			for _, cell := range child.children {
+				if cell.tag != "td" && cell.tag != "th" {
+					continue
+				}
+				sub := &contentRenderer{markdown: r.markdown, lineStart: true}
+				sub.renderChildren(cell)
+				text := strings.Join(strings.Fields(sub.String()), " ")
+				if r.markdown {
+					text = strings.Replace(text, "|", `\|`, -1)
+				}
+				row = append(row, text)
+			}
+			rows = append(rows, row)
+		}
+	}
+	walk(n)
+
+	columns := 0
+	for _, row := range rows {
+		if len(row) > columns {
+			columns = len(row)
+		}
+	}
+	if columns == 0 {
+		return
+	}
+
+	var lines []string
+	for i, row := range rows {
+		for len(row) < columns {
+			row = append(row, "")
+		}
+		if r.markdown {
+			lines = append(lines, "| "+strings.Join(row, " | ")+" |")
+			if i == 0 {
+				lines = append(lines, "|"+strings.Repeat(" --- |", columns))
+			}
+		} else {
+			lines = append(lines, strings.Join(row, " | "))
+		}
+	}
+	r.preformatted(lines)
+}
+
+// preLanguage returns the language of a code block, given by a lang-* class.
+func preLanguage(n *htmlNode) string {
+	for _, class := range strings.Fields(n.attr("class")) {
+		if strings.HasPrefix(class, "lang-") {
+			return strings.TrimPrefix(class, "lang-")
+		}
+	}
+	return ""
+}
+
+// backtickFence returns a fence of at least min backticks longer than every
+// run of backticks in code.
+func backtickFence(code string, min int) string {
+	longest, run := 0, 0
+	for i := 0; i < len(code); i++ {
+		if code[i] == '`' {
+			run++
+			if run > longest {
+				longest = run
+			}
+		} else {
+			run = 0
+		}
+	}
+
+	if longest+1 > min {
+		min = longest + 1
+	}
+	return strings.Repeat("`", min)
+}
+
+// markdownURL escapes the characters of a URL that would end a Markdown link
+// or start raw HTML.
+func markdownURL(u string) string {
+	return strings.NewReplacer(" ", "%20", "(", "%28", ")", "%29", "<", "%3C", ">", "%3E").Replace(u)
+}
+
+// safeElements are the elements kept by SafeHTML, with their allowed
+// attributes.
+var safeElements = map[string][]string{
+	"a": {"href", "title"}, "img": {"src", "alt", "title", "width", "height"},
+	"p": nil, "br": nil, "hr": nil, "div": {"class"}, "span": {"class"}, "center": nil,
+	"b": {"class"}, "strong": nil, "i": nil, "em": nil, "u": nil, "s": nil, "strike": nil, "del": nil,
+	"sub": nil, "sup": nil, "small": nil, "code": nil, "pre": {"class"}, "blockquote": nil,
+	"ul": nil, "ol": {"start"}, "li": nil, "dl": nil, "dt": nil, "dd": nil,
+	"h1": nil, "h2": nil, "h3": nil, "h4": nil, "h5": nil, "h6": nil,
+	"table": nil, "thead": nil, "tbody": nil, "tr": nil, "th": {"colspan", "rowspan"}, "td": {"colspan", "rowspan"},
+}
+
+// droppedElements are the elements removed by SafeHTML with their content.
+var droppedElements = map[string]bool{
+	"script": true, "style": true, "iframe": true, "frame": true, "frameset": true, "object": true,
+	"embed": true, "applet": true, "form": true, "input": true, "button": true, "select": true,
+	"textarea": true, "noscript": true, "template": true, "svg": true, "head": true, "title": true,
+	"meta": true, "link": true, "base": true,
+}
+
+// safeClasses are the classes kept by SafeHTML, besides lang-* classes of
+// code blocks.
+var safeClasses = map[string]bool{
+	"spoiler": true, "spoiler-title": true, "spoiler-content": true, "prettyprint": true,
+}
+
+// SafeHTML returns the content sanitized to a safe subset of HTML for
+// re-rendering: scripts, frames, forms, event handlers and styles are removed,
+// links are made absolute and restricted to http, https and mailto, and
+// unknown elements are replaced by their content. Links get
+// rel="nofollow noopener noreferrer".
+func (c *Content) SafeHTML() string {
+	var b strings.Builder
+	var walk func(n *htmlNode)
+	walk = func(n *htmlNode) {
+		if n.tag == "" {
+			b.WriteString(html.EscapeString(n.text))
+			return
+		}
+		if droppedElements[n.tag] {
+			return
+		}
+
+		attrs, ok := safeElements[n.tag]
+		if !ok {
+			for _, child := range n.children {
+				walk(child)
+			}
+			return
+		}
+
+		b.WriteString("<" + n.tag)
+		for _, name := range attrs {
+			value, ok := n.attrs[name]
+			if !ok {
+				continue
+			}
+
+			switch name {
+			case "href", "src":
+				if value = resolveURL(value); value == "" || (name == "src" && strings.HasPrefix(value, "mailto:")) {
+					continue
+				}
+			case "class":
+				var classes []string
+				for _, class := range strings.Fields(value) {
+					if safeClasses[class] || strings.HasPrefix(class, "lang-") {
+						classes = append(classes, class)
+					}
+				}
+				if len(classes) == 0 {
+					continue
+				}
+				value = strings.Join(classes, " ")
+			}
+			b.WriteString(" " + name + `="` + html.EscapeString(value) + `"`)
+		}
+		if n.tag == "a" {
+			b.WriteString(` rel="nofollow noopener noreferrer"`)
+		}
+		b.WriteString(">")
+
+		if voidElements[n.tag] {
+			return
+		}
+		for _, child := range n.children {
+			walk(child)
+		}
+		b.WriteString("</" + n.tag + ">")
+	}
+
+	for _, child := range c.root.children {
+		walk(child)
+	}
+	return b.String()
+}
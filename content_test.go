@@ -0,0 +1,197 @@
+package codeforces
+
+import (
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestParseHTML(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"text", "a &amp; b", `"a & b"`},
+		{"nested", "<p>a <b>b</b></p>", `p("a " b("b"))`},
+		{"attributes", `<a href="/x" title='t' data-x=y disabled>l</a>`, `a[data-x=y disabled= href=/x title=t]("l")`},
+		{"unclosed", "<p><b>a", `p(b("a"))`},
+		{"stray end tag", "a</b>b", `"a" "b"`},
+		{"less than", "1 <3 and a < b", `"1 " "<" "3 and a " "<" " b"`},
+		{"void", "a<br>b<img src=x>c", `"a" br "b" img[src=x] "c"`},
+		{"self-closing", "<b/>a", `b "a"`},
+		{"comment", "a<!-- <b>x</b> -->b<!doctype html>c", `"a" "b" "c"`},
+		{"raw text", "<script>if (a < b) {}</script>", `script("if (a < b) {}")`},
+		{"tag case", "<P>a</p>", `p("a")`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := dumpHTML(parseHTML(tt.in)); got != tt.want {
+				t.Errorf("parseHTML(%q) = %s, want %s", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+// dumpHTML formats the children of n in a compact form for comparisons.
+func dumpHTML(n *htmlNode) string {
+	parts := make([]string, len(n.children))
+	for i, c := range n.children {
+		if c.tag == "" {
+			parts[i] = `"` + c.text + `"`
+			continue
+		}
+
+		s := c.tag
+		if len(c.attrs) > 0 {
+			var attrs []string
+			for k, v := range c.attrs {
+				attrs = append(attrs, k+"="+v)
+			}
+			sort.Strings(attrs)
+			s += "[" + strings.Join(attrs, " ") + "]"
+		}
+		if len(c.children) > 0 {
+			s += "(" + dumpHTML(c) + ")"
+		}
+		parts[i] = s
+	}
+	return strings.Join(parts, " ")
+}
+
+func TestSafeHTML(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			"javascript link",
+			`<a href="javascript:alert(1)">x</a>`,
+			`<a rel="nofollow noopener noreferrer">x</a>`,
+		},
+		{
+			"obfuscated javascript link",
+			`<a href=" JaVaScRiPt:alert(1)">x</a>`,
+			`<a rel="nofollow noopener noreferrer">x</a>`,
+		},
+		{
+			"event handler",
+			`<img src="/a.png" onerror="alert(1)"><p onclick="alert(1)">p</p>`,
+			`<img src="https://codeforces.com/a.png"><p>p</p>`,
+		},
+		{
+			"script",
+			`a<script>alert(1)</script><iframe src="x"></iframe>b`,
+			`ab`,
+		},
+		{
+			"escaped tags",
+			`<p>$$$&lt;img src=x onerror=alert(1)&gt;$$$</p>`,
+			`<p>$$$&lt;img src=x onerror=alert(1)&gt;$$$</p>`,
+		},
+		{
+			"style and unknown elements",
+			`<span style="color:red" class="x spoiler">a</span><font color="red">b</font>`,
+			`<span class="spoiler">a</span>b`,
+		},
+		{
+			"attribute quoting",
+			`<a href='/x" onmouseover="alert(1)'>x</a>`,
+			`<a href="https://codeforces.com/x%22%20onmouseover=%22alert%281%29" rel="nofollow noopener noreferrer">x</a>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseContent(tt.in).SafeHTML(); got != tt.want {
+				t.Errorf("SafeHTML(%q) =\n%s\nwant\n%s", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarkdown(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			"javascript link",
+			`<a href="javascript:alert(1)">click</a>`,
+			`click`,
+		},
+		{
+			"event handler",
+			`<img src="x" onerror="alert(1)" alt="a">`,
+			`![a](https://codeforces.com/x)`,
+		},
+		{
+			"escaped tags",
+			`<p>&lt;img src=x onerror=alert(1)&gt; &amp;lt;</p>`,
+			`\<img src=x onerror=alert(1)\> \&lt;`,
+		},
+		{
+			"escaped tags in math",
+			`<p>$$$&lt;img src=x onerror=alert(1)&gt;$$$</p>`,
+			`$$$&lt;img src=x onerror=alert(1)&gt;$$$`,
+		},
+		{
+			"math",
+			`<p>$$$a_1 * b_{i}$$$ and a_1</p>`,
+			`$$$a_1 * b_{i}$$$ and a\_1`,
+		},
+		{
+			"unclosed math",
+			`<p>$$$a_1</p><p>&lt;b&gt; a_1</p>`,
+			"$$$a_1\n\n\\<b\\> a\\_1",
+		},
+		{
+			"link destination",
+			`<a href="/x?a=<b>(c)">x</a>`,
+			`[x](https://codeforces.com/x?a=%3Cb%3E%28c%29)`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseContent(tt.in).Markdown(); got != tt.want {
+				t.Errorf("Markdown(%q) =\n%s\nwant\n%s", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContentRoundTrip(t *testing.T) {
+	in := `<p>Thanks to <a href="/profile/tourist" title="Legendary Grandmaster tourist">tourist</a>!</p>` +
+		`<div class="spoiler"><b class="spoiler-title">Hint</b><div class="spoiler-content"><p>Use <b>DP</b>.</p></div></div>` +
+		"<pre class=\"lang-cpp\">int main() {\n  return a &lt; b;\n}</pre>"
+
+	wantMarkdown := "Thanks to [tourist](https://codeforces.com/profile/tourist)!\n\n" +
+		"<details><summary>Hint</summary>\n\nUse **DP**.\n\n</details>\n\n" +
+		"```cpp\nint main() {\n  return a < b;\n}\n```"
+	wantText := "Thanks to tourist!\n\nHint\n\nUse DP.\n\nint main() {\n  return a < b;\n}"
+
+	c := ParseContent(in)
+	safe := c.SafeHTML()
+
+	for _, c := range []*Content{c, ParseContent(safe)} {
+		if got := c.Markdown(); got != wantMarkdown {
+			t.Errorf("Markdown() =\n%s\nwant\n%s", got, wantMarkdown)
+		}
+		if got := c.Text(); got != wantText {
+			t.Errorf("Text() =\n%s\nwant\n%s", got, wantText)
+		}
+
+		refs := c.Refs()
+		if len(refs) != 1 || refs[0].Kind != RefUser || refs[0].Handle != "tourist" {
+			t.Errorf("Refs() = %+v, want the user tourist", refs)
+		}
+	}
+
+	if safe != ParseContent(safe).SafeHTML() {
+		t.Errorf("SafeHTML is not stable:\n%s\n%s", safe, ParseContent(safe).SafeHTML())
+	}
+}
@@ -1,6 +1,7 @@
 package codeforces
 
 import (
+	"context"
 	"strconv"
 )
 
@@ -89,6 +90,10 @@ func GetContestRatingChanges(contestID int) ([]RatingChange, error) {
 //
 // Codeforces API docs: https://codeforces.com/apiHelp/methods#contest.standings
 func (c *Client) GetContestStandings(contestID, from, count int, handles []string, room int, showUnofficial bool) (Contest, []Problem, []RanklistRow, error) {
+	return c.getContestStandings(context.Background(), contestID, from, count, handles, room, showUnofficial)
+}
+
+func (c *Client) getContestStandings(ctx context.Context, contestID, from, count int, handles []string, room int, showUnofficial bool) (Contest, []Problem, []RanklistRow, error) {
 	params := make(map[string][]string)
 
 	params["contestId"] = []string{strconv.FormatInt(int64(contestID), 10)}
@@ -111,7 +116,7 @@ func (c *Client) GetContestStandings(contestID, from, count int, handles []strin
 		Rows     []RanklistRow `json:"rows"`
 	}
 
-	err := c.makeAPICall("contest.standings", params, &res)
+	err := c.makeAPICallContext(ctx, "contest.standings", params, &res)
 
 	return res.Contest, res.Problems, res.Rows, err
 }
@@ -143,6 +148,10 @@ func GetContestStandings(contestID, from, count int, handles []string, room int,
 //
 // Codeforces API docs: https://codeforces.com/apiHelp/methods#contest.status
 func (c *Client) GetContestStatus(contestID int, handle string, from, count int) ([]Submission, error) {
+	return c.getContestStatus(context.Background(), contestID, handle, from, count)
+}
+
+func (c *Client) getContestStatus(ctx context.Context, contestID int, handle string, from, count int) ([]Submission, error) {
 	params := make(map[string][]string)
 
 	params["contestId"] = []string{strconv.FormatInt(int64(contestID), 10)}
@@ -156,7 +165,7 @@ func (c *Client) GetContestStatus(contestID int, handle string, from, count int)
 	}
 
 	var res []Submission
-	err := c.makeAPICall("contest.status", params, &res)
+	err := c.makeAPICallContext(ctx, "contest.status", params, &res)
 
 	return res, err
 }
@@ -0,0 +1,240 @@
+package codeforces
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Division is a set of divisions a contest is intended for, as detected from
+// its name by ContestDivision.
+type Division int
+
+// Divisions detected by ContestDivision. Combined rounds have several bits set,
+// e.g. "Div. 1 + Div. 2" rounds are Div1|Div2.
+const (
+	Div1 Division = 1 << iota
+	Div2
+	Div3
+	Div4
+	Educational
+	Global
+)
+
+var (
+	divisionRegexp    = regexp.MustCompile(`(?i)div\.?\s*([1-4])(?:\s*\+\s*(?:div\.?\s*)?([1-4]))?`)
+	educationalRegexp = regexp.MustCompile(`(?i)\beducational\b`)
+	globalRegexp      = regexp.MustCompile(`(?i)\bglobal round\b`)
+)
+
+// ContestDivision detects the divisions a contest is intended for from its
+// name. Returns 0 if the name mentions no division.
+func ContestDivision(name string) Division {
+	var division Division
+
+	for _, match := range divisionRegexp.FindAllStringSubmatch(name, -1) {
+		for _, d := range match[1:] {
+			if d != "" {
+				division |= Div1 << uint(d[0]-'1')
+			}
+		}
+	}
+
+	if educationalRegexp.MatchString(name) {
+		division |= Educational
+	}
+	if globalRegexp.MatchString(name) {
+		division |= Global
+	}
+
+	return division
+}
+
+// GymFilter selects contests by whether they are gym contests.
+type GymFilter int
+
+// Values of ContestFilter.Gym.
+const (
+	// AnyGym matches both gym and regular contests.
+	AnyGym GymFilter = iota
+
+	// OnlyGym matches only gym contests.
+	OnlyGym
+
+	// NoGym matches only regular contests.
+	NoGym
+)
+
+// ContestFilter selects contests. Every non-zero field restricts the matched
+// contests; the zero value matches every contest.
+type ContestFilter struct {
+	// Phases and Types restrict Contest.Phase and Contest.Type.
+	Phases []string
+	Types  []string
+
+	// StartAfter and StartBefore restrict the start time of contests.
+	// Contests without a start time never match a time window.
+	StartAfter  time.Time
+	StartBefore time.Time
+
+	// NameContains is matched case-insensitively against Contest.Name.
+	NameContains string
+
+	// Name is matched against Contest.Name.
+	Name *regexp.Regexp
+
+	// Division matches contests sharing at least one division with it.
+	Division Division
+
+	// Gym restricts whether contests are gym contests.
+	Gym GymFilter
+
+	// Kinds, IcpcRegions, Countries, Cities and Seasons restrict the gym
+	// fields of contests.
+	Kinds       []string
+	IcpcRegions []string
+	Countries   []string
+	Cities      []string
+	Seasons     []string
+
+	// MinDifficulty and MaxDifficulty restrict Contest.Difficulty.
+	MinDifficulty int
+	MaxDifficulty int
+}
+
+// Match reports whether contest matches the filter.
+func (f ContestFilter) Match(contest Contest) bool {
+	if len(f.Phases) > 0 && !containsString(f.Phases, contest.Phase) {
+		return false
+	}
+	if len(f.Types) > 0 && !containsString(f.Types, contest.Type) {
+		return false
+	}
+
+	if !f.StartAfter.IsZero() || !f.StartBefore.IsZero() {
+		if contest.StartTimeSeconds == 0 {
+			return false
+		}
+
+		start := time.Unix(int64(contest.StartTimeSeconds), 0)
+		if !f.StartAfter.IsZero() && start.Before(f.StartAfter) {
+			return false
+		}
+		if !f.StartBefore.IsZero() && !start.Before(f.StartBefore) {
+			return false
+		}
+	}
+
+	if f.NameContains != "" && !strings.Contains(strings.ToLower(contest.Name), strings.ToLower(f.NameContains)) {
+		return false
+	}
+	if f.Name != nil && !f.Name.MatchString(contest.Name) {
+		return false
+	}
+	if f.Division != 0 && ContestDivision(contest.Name)&f.Division == 0 {
+		return false
+	}
+
+	if (f.Gym == OnlyGym && !isGym(contest.ID)) || (f.Gym == NoGym && isGym(contest.ID)) {
+		return false
+	}
+	if len(f.Kinds) > 0 && !containsString(f.Kinds, contest.Kind) {
+		return false
+	}
+	if len(f.IcpcRegions) > 0 && !containsString(f.IcpcRegions, contest.IcpcRegion) {
+		return false
+	}
+	if len(f.Countries) > 0 && !containsString(f.Countries, contest.Country) {
+		return false
+	}
+	if len(f.Cities) > 0 && !containsString(f.Cities, contest.City) {
+		return false
+	}
+	if len(f.Seasons) > 0 && !containsString(f.Seasons, contest.Season) {
+		return false
+	}
+	if f.MinDifficulty != 0 && contest.Difficulty < f.MinDifficulty {
+		return false
+	}
+	if f.MaxDifficulty != 0 && contest.Difficulty > f.MaxDifficulty {
+		return false
+	}
+
+	return true
+}
+
+// Filter returns the contests matching the filter, in their original order.
+func (f ContestFilter) Filter(contests []Contest) []Contest {
+	var res []Contest
+	for _, contest := range contests {
+		if f.Match(contest) {
+			res = append(res, contest)
+		}
+	}
+
+	return res
+}
+
+// ContestOrder is a sort order for contests.
+type ContestOrder int
+
+// Sort orders accepted by SortContests.
+const (
+	ContestsByStartTime ContestOrder = iota
+	ContestsByStartTimeDesc
+	ContestsByID
+	ContestsByIDDesc
+	ContestsByName
+	ContestsByDuration
+)
+
+// SortContests sorts contests in place. Contests comparing equal keep their
+// relative order.
+func SortContests(contests []Contest, order ContestOrder) {
+	sort.SliceStable(contests, func(i, j int) bool {
+		a, b := contests[i], contests[j]
+
+		switch order {
+		case ContestsByStartTimeDesc:
+			return a.StartTimeSeconds > b.StartTimeSeconds
+		case ContestsByID:
+			return a.ID < b.ID
+		case ContestsByIDDesc:
+			return a.ID > b.ID
+		case ContestsByName:
+			return a.Name < b.Name
+		case ContestsByDuration:
+			return a.DurationSeconds < b.DurationSeconds
+		default:
+			return a.StartTimeSeconds < b.StartTimeSeconds
+		}
+	})
+}
+
+// GetAllContestList returns information about all available contests, both gym
+// and non-gym, most recent first.
+func (c *Client) GetAllContestList() ([]Contest, error) {
+	contests, err := c.GetContestList(false)
+	if err != nil {
+		return nil, err
+	}
+
+	gymContests, err := c.GetContestList(true)
+	if err != nil {
+		return nil, err
+	}
+
+	contests = append(contests, gymContests...)
+	SortContests(contests, ContestsByStartTimeDesc)
+
+	return contests, nil
+}
+
+// GetAllContestList returns information about all available contests, both gym
+// and non-gym, most recent first.
+//
+// GetAllContestList is a wrapper around DefaultClient.GetAllContestList.
+func GetAllContestList() ([]Contest, error) {
+	return DefaultClient.GetAllContestList()
+}
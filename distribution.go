@@ -0,0 +1,215 @@
+package codeforces
+
+import (
+	"sort"
+)
+
+// Grouping selects the field users are grouped by in a RatingDistribution.
+type Grouping int
+
+// Groupings of a RatingDistribution. GroupAll has a single group named "".
+const (
+	GroupAll Grouping = iota
+	GroupByCountry
+	GroupByCity
+	GroupByOrganization
+)
+
+func (g Grouping) key(user User) string {
+	switch g {
+	case GroupByCountry:
+		return user.Country
+	case GroupByCity:
+		return user.City
+	case GroupByOrganization:
+		return user.Organization
+	default:
+		return ""
+	}
+}
+
+// ratingCounts counts users by exact rating.
+type ratingCounts struct {
+	total  int
+	counts map[int]int
+}
+
+// RatingDistribution is the distribution of ratings of users, grouped by
+// country, city and organization. Users are added one at a time and only
+// counts are kept, so that the full rated list never has to be held in memory.
+type RatingDistribution struct {
+	groups map[Grouping]map[string]*ratingCounts
+}
+
+// HistogramBucket is a bucket of a rating histogram, counting users with a
+// rating in [MinRating, MinRating+bucket size).
+type HistogramBucket struct {
+	MinRating int
+	Count     int
+}
+
+// NewRatingDistribution creates an empty RatingDistribution.
+func NewRatingDistribution() *RatingDistribution {
+	d := &RatingDistribution{groups: make(map[Grouping]map[string]*ratingCounts)}
+	for _, g := range []Grouping{GroupAll, GroupByCountry, GroupByCity, GroupByOrganization} {
+		d.groups[g] = make(map[string]*ratingCounts)
+	}
+
+	return d
+}
+
+// Add adds a user to the distribution.
+func (d *RatingDistribution) Add(user User) {
+	for g, groups := range d.groups {
+		key := g.key(user)
+
+		counts, ok := groups[key]
+		if !ok {
+			counts = &ratingCounts{counts: make(map[int]int)}
+			groups[key] = counts
+		}
+
+		counts.total++
+		counts.counts[user.Rating]++
+	}
+}
+
+// GetRatingDistribution computes the rating distribution of rated users,
+// streaming the rated list with StreamUserRatedList.
+func (c *Client) GetRatingDistribution(activeOnly bool) (*RatingDistribution, error) {
+	d := NewRatingDistribution()
+
+	err := c.StreamUserRatedList(activeOnly, func(user User) error {
+		d.Add(user)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// GetRatingDistribution computes the rating distribution of rated users.
+//
+// GetRatingDistribution is a wrapper around DefaultClient.GetRatingDistribution.
+func GetRatingDistribution(activeOnly bool) (*RatingDistribution, error) {
+	return DefaultClient.GetRatingDistribution(activeOnly)
+}
+
+// Groups returns the names of the groups of a grouping, sorted by decreasing
+// number of users.
+func (d *RatingDistribution) Groups(g Grouping) []string {
+	groups := d.groups[g]
+
+	res := make([]string, 0, len(groups))
+	for name := range groups {
+		res = append(res, name)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		if groups[res[i]].total != groups[res[j]].total {
+			return groups[res[i]].total > groups[res[j]].total
+		}
+		return res[i] < res[j]
+	})
+
+	return res
+}
+
+// Count returns the number of users in a group.
+func (d *RatingDistribution) Count(g Grouping, name string) int {
+	if counts, ok := d.groups[g][name]; ok {
+		return counts.total
+	}
+	return 0
+}
+
+// Histogram returns the histogram of the ratings of a group with buckets of
+// bucketSize rating points, from the lowest to the highest non-empty bucket.
+// It returns nil for an empty group or a non-positive bucketSize.
+func (d *RatingDistribution) Histogram(g Grouping, name string, bucketSize int) []HistogramBucket {
+	counts, ok := d.groups[g][name]
+	if !ok || bucketSize <= 0 {
+		return nil
+	}
+
+	buckets := make(map[int]int)
+	for rating, n := range counts.counts {
+		buckets[floorDiv(rating, bucketSize)*bucketSize] += n
+	}
+	if len(buckets) == 0 {
+		return nil
+	}
+
+	keys := make([]int, 0, len(buckets))
+	for k := range buckets {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+
+	var res []HistogramBucket
+	for rating := keys[0]; rating <= keys[len(keys)-1]; rating += bucketSize {
+		res = append(res, HistogramBucket{MinRating: rating, Count: buckets[rating]})
+	}
+
+	return res
+}
+
+// Percentile returns the percentage of users of a group with a rating strictly
+// lower than rating, i.e. "what percentile is rating in this group".
+func (d *RatingDistribution) Percentile(g Grouping, name string, rating int) float64 {
+	counts, ok := d.groups[g][name]
+	if !ok || counts.total == 0 {
+		return 0
+	}
+
+	below := 0
+	for r, n := range counts.counts {
+		if r < rating {
+			below += n
+		}
+	}
+
+	return 100 * float64(below) / float64(counts.total)
+}
+
+// PercentileRatings returns, for each percentile p in percentiles, the lowest
+// rating of a group such that at least p percent of the users of the group
+// have a rating lower or equal to it.
+func (d *RatingDistribution) PercentileRatings(g Grouping, name string, percentiles []float64) []int {
+	res := make([]int, len(percentiles))
+
+	counts, ok := d.groups[g][name]
+	if !ok || counts.total == 0 {
+		return res
+	}
+
+	ratings := make([]int, 0, len(counts.counts))
+	for r := range counts.counts {
+		ratings = append(ratings, r)
+	}
+	sort.Ints(ratings)
+
+	for i, p := range percentiles {
+		seen := 0
+		for _, r := range ratings {
+			seen += counts.counts[r]
+			res[i] = r
+			if 100*float64(seen) >= p*float64(counts.total) {
+				break
+			}
+		}
+	}
+
+	return res
+}
+
+// floorDiv divides a by b rounding toward negative infinity.
+func floorDiv(a, b int) int {
+	q := a / b
+	if a%b != 0 && (a < 0) != (b < 0) {
+		q--
+	}
+	return q
+}
@@ -0,0 +1,487 @@
+package codeforces
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ExportSchemaVersion is the version of the column schemas used by the
+// exporters. It is written in the schema_version column of every record.
+//
+// Columns are only ever appended to a schema. The version is incremented when
+// a column is removed, renamed or changes meaning, so that loads depending on
+// the previous layout can detect it.
+const ExportSchemaVersion = 1
+
+// ExportFormat is an output format of the exporters.
+type ExportFormat int
+
+// Output formats of the exporters.
+const (
+	// ExportCSV writes a header row followed by one row per record.
+	ExportCSV ExportFormat = iota
+
+	// ExportJSONLines writes one JSON object per line, keyed by column name.
+	ExportJSONLines
+)
+
+// ExportColumn is a column of an export schema. Type is one of "int64",
+// "double", "string" and "bool", which map directly to columnar formats such as
+// Parquet. List values, like tags or team members, are exported as strings
+// joined with ";".
+type ExportColumn struct {
+	Name string
+	Type string
+}
+
+// ErrColumnCount is returned by TableWriter.Write when the number of values
+// does not match the number of columns.
+var ErrColumnCount = errors.New("codeforces: wrong number of values for export schema")
+
+// TableWriter writes records with a fixed column schema in an ExportFormat.
+// The schema_version column is prepended to the columns.
+type TableWriter struct {
+	format  ExportFormat
+	columns []ExportColumn
+
+	csv  *csv.Writer
+	json *json.Encoder
+
+	wroteHeader bool
+}
+
+// NewTableWriter creates a TableWriter writing records with the given columns
+// to w.
+func NewTableWriter(w io.Writer, format ExportFormat, columns []ExportColumn) *TableWriter {
+	t := &TableWriter{
+		format:  format,
+		columns: append([]ExportColumn{{"schema_version", "int64"}}, columns...),
+	}
+
+	if format == ExportJSONLines {
+		t.json = json.NewEncoder(w)
+	} else {
+		t.csv = csv.NewWriter(w)
+	}
+
+	return t
+}
+
+// Write writes a record. values must hold one value per column, of type int,
+// float64, string or bool.
+func (t *TableWriter) Write(values []interface{}) error {
+	if len(values)+1 != len(t.columns) {
+		return ErrColumnCount
+	}
+	values = append([]interface{}{ExportSchemaVersion}, values...)
+
+	if t.format == ExportJSONLines {
+		record := make(map[string]interface{}, len(values))
+		for i, v := range values {
+			record[t.columns[i].Name] = v
+		}
+		return t.json.Encode(record)
+	}
+
+	if err := t.writeHeader(); err != nil {
+		return err
+	}
+
+	row := make([]string, len(values))
+	for i, v := range values {
+		row[i] = formatExportValue(v)
+	}
+	return t.csv.Write(row)
+}
+
+// writeHeader writes the CSV header row unless it was already written.
+func (t *TableWriter) writeHeader() error {
+	if t.wroteHeader {
+		return nil
+	}
+
+	header := make([]string, len(t.columns))
+	for i, c := range t.columns {
+		header[i] = c.Name
+	}
+	if err := t.csv.Write(header); err != nil {
+		return err
+	}
+	t.wroteHeader = true
+	return nil
+}
+
+// Flush writes any buffered data to the underlying writer. In CSV, the header
+// row is written even if no record was, so that the schema is always known.
+func (t *TableWriter) Flush() error {
+	if t.csv != nil {
+		if err := t.writeHeader(); err != nil {
+			return err
+		}
+		t.csv.Flush()
+		return t.csv.Error()
+	}
+	return nil
+}
+
+func formatExportValue(v interface{}) string {
+	switch v := v.(type) {
+	case int:
+		return strconv.Itoa(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case string:
+		return v
+	default:
+		return ""
+	}
+}
+
+func exportHandles(party Party) string {
+	handles := make([]string, len(party.Members))
+	for i, member := range party.Members {
+		handles[i] = member.Handle
+	}
+	return strings.Join(handles, ";")
+}
+
+// partyColumns are the columns of a flattened Party, prefixed by its role.
+func partyColumns(prefix string) []ExportColumn {
+	return []ExportColumn{
+		{prefix + "_handles", "string"},
+		{prefix + "_participant_type", "string"},
+		{prefix + "_team_id", "int64"},
+		{prefix + "_team_name", "string"},
+		{prefix + "_ghost", "bool"},
+		{prefix + "_room", "int64"},
+		{prefix + "_start_time_seconds", "int64"},
+	}
+}
+
+func partyValues(party Party) []interface{} {
+	return []interface{}{
+		exportHandles(party),
+		party.ParticipantType,
+		party.TeamID,
+		party.TeamName,
+		party.Ghost,
+		party.Room,
+		party.StartTimeSeconds,
+	}
+}
+
+// problemColumns are the columns of a flattened Problem, prefixed by its role.
+func problemColumns(prefix string) []ExportColumn {
+	return []ExportColumn{
+		{prefix + "_contest_id", "int64"},
+		{prefix + "_problemset_name", "string"},
+		{prefix + "_index", "string"},
+		{prefix + "_name", "string"},
+		{prefix + "_type", "string"},
+		{prefix + "_points", "double"},
+		{prefix + "_rating", "int64"},
+		{prefix + "_tags", "string"},
+	}
+}
+
+func problemValues(problem Problem) []interface{} {
+	return []interface{}{
+		problem.ContestID,
+		problem.ProblemsetName,
+		problem.Index,
+		problem.Name,
+		problem.Type,
+		problem.Points,
+		problem.Rating,
+		strings.Join(problem.Tags, ";"),
+	}
+}
+
+// submissionColumns is the export schema of submissions.
+var submissionColumns = concatColumns(
+	[]ExportColumn{
+		{"id", "int64"},
+		{"contest_id", "int64"},
+		{"creation_time_seconds", "int64"},
+		{"relative_time_seconds", "int64"},
+	},
+	problemColumns("problem"),
+	partyColumns("author"),
+	[]ExportColumn{
+		{"programming_language", "string"},
+		{"verdict", "string"},
+		{"testset", "string"},
+		{"passed_test_count", "int64"},
+		{"time_consumed_millis", "int64"},
+		{"memory_consumed_bytes", "int64"},
+		{"points", "double"},
+	},
+)
+
+// SubmissionColumns returns the export schema of submissions.
+func SubmissionColumns() []ExportColumn {
+	return append([]ExportColumn(nil), submissionColumns...)
+}
+
+// SubmissionValues flattens a submission according to SubmissionColumns.
+func SubmissionValues(s Submission) []interface{} {
+	return concatValues(
+		[]interface{}{s.ID, s.ContestID, s.CreationTimeSeconds, s.RelativeTimeSeconds},
+		problemValues(s.Problem),
+		partyValues(s.Author),
+		[]interface{}{s.ProgrammingLanguage, s.Verdict, s.Testset, s.PassedTestCount, s.TimeConsumedMillis, s.MemoryConsumedBytes, s.Points},
+	)
+}
+
+// RanklistRowColumns returns the export schema of the rows of a ranklist with
+// the given problems. Each problem adds the columns of its ProblemResult,
+// prefixed by "problem_" and the problem index.
+func RanklistRowColumns(problems []Problem) []ExportColumn {
+	columns := concatColumns(
+		partyColumns("party"),
+		[]ExportColumn{
+			{"rank", "int64"},
+			{"points", "double"},
+			{"penalty", "int64"},
+			{"successful_hack_count", "int64"},
+			{"unsuccessful_hack_count", "int64"},
+			{"last_submission_time_seconds", "int64"},
+		},
+	)
+
+	for _, problem := range problems {
+		prefix := "problem_" + strings.ToLower(problem.Index)
+		columns = append(columns,
+			ExportColumn{prefix + "_points", "double"},
+			ExportColumn{prefix + "_penalty", "int64"},
+			ExportColumn{prefix + "_rejected_attempt_count", "int64"},
+			ExportColumn{prefix + "_type", "string"},
+			ExportColumn{prefix + "_best_submission_time_seconds", "int64"},
+		)
+	}
+
+	return columns
+}
+
+// RanklistRowValues flattens a ranklist row according to RanklistRowColumns.
+// Missing problem results are exported as zero values.
+func RanklistRowValues(row RanklistRow, problems []Problem) []interface{} {
+	values := concatValues(
+		partyValues(row.Party),
+		[]interface{}{row.Rank, row.Points, row.Penalty, row.SuccessfulHackCount, row.UnsuccessfulHackCount, row.LastSubmissionTimeSeconds},
+	)
+
+	for i := range problems {
+		var result ProblemResult
+		if i < len(row.ProblemResults) {
+			result = row.ProblemResults[i]
+		}
+		values = append(values, result.Points, result.Penalty, result.RejectedAttemptCount, result.Type, result.BestSubmissionTimeSeconds)
+	}
+
+	return values
+}
+
+// ratingChangeColumns is the export schema of rating changes.
+var ratingChangeColumns = []ExportColumn{
+	{"contest_id", "int64"},
+	{"contest_name", "string"},
+	{"handle", "string"},
+	{"rank", "int64"},
+	{"rating_update_time_seconds", "int64"},
+	{"old_rating", "int64"},
+	{"new_rating", "int64"},
+}
+
+// RatingChangeColumns returns the export schema of rating changes.
+func RatingChangeColumns() []ExportColumn {
+	return append([]ExportColumn(nil), ratingChangeColumns...)
+}
+
+// RatingChangeValues flattens a rating change according to
+// RatingChangeColumns.
+func RatingChangeValues(c RatingChange) []interface{} {
+	return []interface{}{c.ContestID, c.ContestName, c.Handle, c.Rank, c.RatingUpdateTimeSeconds, c.OldRating, c.NewRating}
+}
+
+// userColumns is the export schema of users.
+var userColumns = []ExportColumn{
+	{"handle", "string"},
+	{"email", "string"},
+	{"vk_id", "string"},
+	{"open_id", "string"},
+	{"first_name", "string"},
+	{"last_name", "string"},
+	{"country", "string"},
+	{"city", "string"},
+	{"organization", "string"},
+	{"contribution", "int64"},
+	{"rank", "string"},
+	{"rating", "int64"},
+	{"max_rank", "string"},
+	{"max_rating", "int64"},
+	{"last_online_time_seconds", "int64"},
+	{"registration_time_seconds", "int64"},
+	{"friend_of_count", "int64"},
+	{"avatar", "string"},
+	{"title_photo", "string"},
+}
+
+// UserColumns returns the export schema of users.
+func UserColumns() []ExportColumn {
+	return append([]ExportColumn(nil), userColumns...)
+}
+
+// UserValues flattens a user according to UserColumns.
+func UserValues(u User) []interface{} {
+	return []interface{}{
+		u.Handle, u.Email, u.VkID, u.OpenID, u.FirstName, u.LastName, u.Country, u.City, u.Organization,
+		u.Contribution, u.Rank, u.Rating, u.MaxRank, u.MaxRating, u.LastOnlineTimeSeconds,
+		u.RegistrationTimeSeconds, u.FriendOfCount, u.Avatar, u.TitlePhoto,
+	}
+}
+
+// hackColumns is the export schema of hacks.
+var hackColumns = concatColumns(
+	[]ExportColumn{
+		{"id", "int64"},
+		{"creation_time_seconds", "int64"},
+	},
+	partyColumns("hacker"),
+	partyColumns("defender"),
+	[]ExportColumn{{"verdict", "string"}},
+	problemColumns("problem"),
+	[]ExportColumn{
+		{"test", "string"},
+		{"judge_protocol_manual", "string"},
+		{"judge_protocol_protocol", "string"},
+		{"judge_protocol_verdict", "string"},
+	},
+)
+
+// HackColumns returns the export schema of hacks.
+func HackColumns() []ExportColumn {
+	return append([]ExportColumn(nil), hackColumns...)
+}
+
+// HackValues flattens a hack according to HackColumns.
+func HackValues(h Hack) []interface{} {
+	return concatValues(
+		[]interface{}{h.ID, h.CreationTimeSeconds},
+		partyValues(h.Hacker),
+		partyValues(h.Defender),
+		[]interface{}{h.Verdict},
+		problemValues(h.Problem),
+		[]interface{}{h.Test, h.JudgeProtocol.Manual, h.JudgeProtocol.Protocol, h.JudgeProtocol.Verdict},
+	)
+}
+
+// commentColumns is the export schema of comments.
+var commentColumns = []ExportColumn{
+	{"id", "int64"},
+	{"creation_time_seconds", "int64"},
+	{"commentator_handle", "string"},
+	{"locale", "string"},
+	{"text", "string"},
+	{"parent_comment_id", "int64"},
+	{"rating", "int64"},
+}
+
+// CommentColumns returns the export schema of comments.
+func CommentColumns() []ExportColumn {
+	return append([]ExportColumn(nil), commentColumns...)
+}
+
+// CommentValues flattens a comment according to CommentColumns.
+func CommentValues(c Comment) []interface{} {
+	return []interface{}{c.ID, c.CreationTimeSeconds, c.CommentatorHandle, c.Locale, c.Text, c.ParentCommentID, c.Rating}
+}
+
+// ExportSubmissions writes submissions to w in the given format.
+func ExportSubmissions(w io.Writer, format ExportFormat, submissions []Submission) error {
+	t := NewTableWriter(w, format, submissionColumns)
+	for _, s := range submissions {
+		if err := t.Write(SubmissionValues(s)); err != nil {
+			return err
+		}
+	}
+	return t.Flush()
+}
+
+// ExportRanklist writes the rows of a ranklist with the given problems to w in
+// the given format.
+func ExportRanklist(w io.Writer, format ExportFormat, problems []Problem, rows []RanklistRow) error {
+	t := NewTableWriter(w, format, RanklistRowColumns(problems))
+	for _, row := range rows {
+		if err := t.Write(RanklistRowValues(row, problems)); err != nil {
+			return err
+		}
+	}
+	return t.Flush()
+}
+
+// ExportRatingChanges writes rating changes to w in the given format.
+func ExportRatingChanges(w io.Writer, format ExportFormat, changes []RatingChange) error {
+	t := NewTableWriter(w, format, ratingChangeColumns)
+	for _, c := range changes {
+		if err := t.Write(RatingChangeValues(c)); err != nil {
+			return err
+		}
+	}
+	return t.Flush()
+}
+
+// ExportUsers writes users to w in the given format.
+func ExportUsers(w io.Writer, format ExportFormat, users []User) error {
+	t := NewTableWriter(w, format, userColumns)
+	for _, u := range users {
+		if err := t.Write(UserValues(u)); err != nil {
+			return err
+		}
+	}
+	return t.Flush()
+}
+
+// ExportHacks writes hacks to w in the given format.
+func ExportHacks(w io.Writer, format ExportFormat, hacks []Hack) error {
+	t := NewTableWriter(w, format, hackColumns)
+	for _, h := range hacks {
+		if err := t.Write(HackValues(h)); err != nil {
+			return err
+		}
+	}
+	return t.Flush()
+}
+
+// ExportComments writes comments to w in the given format.
+func ExportComments(w io.Writer, format ExportFormat, comments []Comment) error {
+	t := NewTableWriter(w, format, commentColumns)
+	for _, c := range comments {
+		if err := t.Write(CommentValues(c)); err != nil {
+			return err
+		}
+	}
+	return t.Flush()
+}
+
+func concatColumns(lists ...[]ExportColumn) []ExportColumn {
+	var res []ExportColumn
+	for _, list := range lists {
+		res = append(res, list...)
+	}
+	return res
+}
+
+func concatValues(lists ...[]interface{}) []interface{} {
+	var res []interface{}
+	for _, list := range lists {
+		res = append(res, list...)
+	}
+	return res
+}
@@ -0,0 +1,257 @@
+package codeforces
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// HackReport summarizes the hacks made during a contest.
+type HackReport struct {
+	Contest      Contest
+	Total        int
+	Successful   int
+	Unsuccessful int
+
+	// Hackers are the parties who made hacks, by decreasing number of
+	// successful hacks.
+	Hackers []PartyHackStats
+
+	// Defenders are the parties whose solutions were hacked, by decreasing
+	// number of successful hacks against them.
+	Defenders []PartyHackStats
+
+	// Problems are the hacked problems, by decreasing number of successful
+	// hacks.
+	Problems []ProblemHackStats
+
+	// Timeline counts hacks in consecutive periods from the start of the
+	// contest.
+	Timeline []HackTimelineBucket
+
+	hackers map[string]*PartyHackStats
+}
+
+// PartyHackStats counts the hacks made by or against a party.
+type PartyHackStats struct {
+	Party        Party
+	Successful   int
+	Unsuccessful int
+}
+
+// ProblemHackStats counts the hacks against the solutions of a problem.
+type ProblemHackStats struct {
+	Problem      Problem
+	Total        int
+	Successful   int
+	Unsuccessful int
+}
+
+// SuccessRate returns the ratio of successful hacks among all hacks of the
+// problem.
+func (s ProblemHackStats) SuccessRate() float64 {
+	if s.Total == 0 {
+		return 0
+	}
+	return float64(s.Successful) / float64(s.Total)
+}
+
+// HackTimelineBucket counts the hacks made during a period of a contest.
+type HackTimelineBucket struct {
+	// Start is the start of the period, relative to the start of the contest.
+	Start        time.Duration
+	Successful   int
+	Unsuccessful int
+}
+
+// HackDiscrepancy is a party whose hack counts in the ranklist differ from the
+// counts computed from the hacks.
+type HackDiscrepancy struct {
+	Party                Party
+	RanklistSuccessful   int
+	RanklistUnsuccessful int
+	HacksSuccessful      int
+	HacksUnsuccessful    int
+}
+
+// NewHackReport computes the hack report of contest from its hacks, as
+// returned by GetContestHacks. The timeline is split into periods of length
+// interval.
+func NewHackReport(contest Contest, hacks []Hack, interval time.Duration) *HackReport {
+	r := &HackReport{
+		Contest: contest,
+		hackers: make(map[string]*PartyHackStats),
+	}
+
+	defenders := make(map[string]*PartyHackStats)
+	problems := make(map[ProblemID]*ProblemHackStats)
+
+	for _, hack := range hacks {
+		successful := hack.Verdict == "HACK_SUCCESSFUL"
+		unsuccessful := hack.Verdict == "HACK_UNSUCCESSFUL"
+
+		r.Total++
+		hacker := partyHackStats(r.hackers, hack.Hacker)
+		defender := partyHackStats(defenders, hack.Defender)
+
+		id := hack.Problem.ProblemID()
+		problem, ok := problems[id]
+		if !ok {
+			problem = &ProblemHackStats{Problem: hack.Problem}
+			problems[id] = problem
+		}
+		problem.Total++
+
+		if successful {
+			r.Successful++
+			hacker.Successful++
+			defender.Successful++
+			problem.Successful++
+		} else if unsuccessful {
+			r.Unsuccessful++
+			hacker.Unsuccessful++
+			defender.Unsuccessful++
+			problem.Unsuccessful++
+		}
+
+		if interval > 0 && contest.StartTimeSeconds != 0 && (successful || unsuccessful) {
+			elapsed := time.Duration(hack.CreationTimeSeconds-contest.StartTimeSeconds) * time.Second
+			if elapsed < 0 {
+				elapsed = 0
+			}
+
+			i := int(elapsed / interval)
+			for len(r.Timeline) <= i {
+				r.Timeline = append(r.Timeline, HackTimelineBucket{Start: time.Duration(len(r.Timeline)) * interval})
+			}
+
+			if successful {
+				r.Timeline[i].Successful++
+			} else {
+				r.Timeline[i].Unsuccessful++
+			}
+		}
+	}
+
+	r.Hackers = sortedPartyHackStats(r.hackers)
+	r.Defenders = sortedPartyHackStats(defenders)
+
+	for _, problem := range problems {
+		r.Problems = append(r.Problems, *problem)
+	}
+	sort.Slice(r.Problems, func(i, j int) bool {
+		if r.Problems[i].Successful != r.Problems[j].Successful {
+			return r.Problems[i].Successful > r.Problems[j].Successful
+		}
+		return r.Problems[i].Problem.ProblemID().Less(r.Problems[j].Problem.ProblemID())
+	})
+
+	return r
+}
+
+// GetHackReport returns the hack report of a contest.
+func (c *Client) GetHackReport(contestID int, interval time.Duration) (*HackReport, error) {
+	contest, _, _, err := c.GetContestStandings(contestID, 1, 1, nil, 0, false)
+	if err != nil {
+		return nil, err
+	}
+
+	hacks, err := c.GetContestHacks(contestID)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewHackReport(contest, hacks, interval), nil
+}
+
+// GetHackReport returns the hack report of a contest.
+//
+// GetHackReport is a wrapper around DefaultClient.GetHackReport.
+func GetHackReport(contestID int, interval time.Duration) (*HackReport, error) {
+	return DefaultClient.GetHackReport(contestID, interval)
+}
+
+// CrossCheck compares the hack counts of the report with SuccessfulHackCount
+// and UnsuccessfulHackCount of the ranklist rows, and returns the parties
+// whose counts differ.
+func (r *HackReport) CrossCheck(rows []RanklistRow) []HackDiscrepancy {
+	var res []HackDiscrepancy
+
+	seen := make(map[string]bool)
+	for _, row := range rows {
+		key := partyKey(row.Party)
+		seen[key] = true
+
+		var stats PartyHackStats
+		if s, ok := r.hackers[key]; ok {
+			stats = *s
+		}
+
+		if row.SuccessfulHackCount != stats.Successful || row.UnsuccessfulHackCount != stats.Unsuccessful {
+			res = append(res, HackDiscrepancy{
+				Party:                row.Party,
+				RanklistSuccessful:   row.SuccessfulHackCount,
+				RanklistUnsuccessful: row.UnsuccessfulHackCount,
+				HacksSuccessful:      stats.Successful,
+				HacksUnsuccessful:    stats.Unsuccessful,
+			})
+		}
+	}
+
+	for _, stats := range r.Hackers {
+		if !seen[partyKey(stats.Party)] && (stats.Successful != 0 || stats.Unsuccessful != 0) {
+			res = append(res, HackDiscrepancy{
+				Party:             stats.Party,
+				HacksSuccessful:   stats.Successful,
+				HacksUnsuccessful: stats.Unsuccessful,
+			})
+		}
+	}
+
+	return res
+}
+
+func partyHackStats(stats map[string]*PartyHackStats, party Party) *PartyHackStats {
+	key := partyKey(party)
+
+	s, ok := stats[key]
+	if !ok {
+		s = &PartyHackStats{Party: party}
+		stats[key] = s
+	}
+
+	return s
+}
+
+func sortedPartyHackStats(stats map[string]*PartyHackStats) []PartyHackStats {
+	res := make([]PartyHackStats, 0, len(stats))
+	for _, s := range stats {
+		res = append(res, *s)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Successful != res[j].Successful {
+			return res[i].Successful > res[j].Successful
+		}
+		if res[i].Unsuccessful != res[j].Unsuccessful {
+			return res[i].Unsuccessful < res[j].Unsuccessful
+		}
+		return partyName(res[i].Party) < partyName(res[j].Party)
+	})
+
+	return res
+}
+
+// partyName returns the team name of a party, or the handles of its members.
+func partyName(party Party) string {
+	if party.TeamName != "" {
+		return party.TeamName
+	}
+
+	handles := make([]string, len(party.Members))
+	for i, member := range party.Members {
+		handles[i] = member.Handle
+	}
+
+	return strings.Join(handles, ", ")
+}
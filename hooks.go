@@ -0,0 +1,227 @@
+package codeforces
+
+import (
+	"io"
+	"time"
+)
+
+// redacted replaces the value of secret parameters passed to hooks.
+const redacted = "REDACTED"
+
+// APICall describes an API call to hooks. The same APICall is passed to
+// BeforeRequest and AfterResponse; fields describing the response are set
+// before AfterResponse.
+type APICall struct {
+	// Method is the API method called, such as "user.info".
+	Method string
+
+	// Params are the parameters of the call, including those added by the
+	// client, with apiKey and apiSig redacted.
+	Params map[string][]string
+
+	// Start is the time the request was sent.
+	Start time.Time
+
+	// Duration is the time from Start until the response was read.
+	Duration time.Duration
+
+	// HTTPStatus is the HTTP status code of the response, or 0 if no response
+	// was received.
+	HTTPStatus int
+
+	// Status is the status of the API response, "OK" or "FAILED", or empty if
+	// it was not read.
+	Status string
+
+	// Comment is the reason of the failure given by Codeforces.
+	Comment string
+
+	// Bytes is the number of bytes of the response body read.
+	Bytes int64
+
+	// Err is the error returned by the call, if any.
+	Err error
+
+	values map[interface{}]interface{}
+}
+
+// SetValue associates a value with key for the duration of the call, so that
+// hooks can pass state from BeforeRequest to AfterResponse.
+func (c *APICall) SetValue(key, value interface{}) {
+	if c.values == nil {
+		c.values = make(map[interface{}]interface{})
+	}
+	c.values[key] = value
+}
+
+// Value returns the value associated with key by SetValue, or nil.
+func (c *APICall) Value(key interface{}) interface{} {
+	return c.values[key]
+}
+
+// Hook is notified of the API calls made by a client. Hooks are called
+// synchronously from the goroutine making the call, so they should not block.
+type Hook interface {
+	// BeforeRequest is called before the request of a call is sent.
+	BeforeRequest(call *APICall)
+
+	// AfterResponse is called once the call is done, whether it succeeded or
+	// not.
+	AfterResponse(call *APICall)
+}
+
+// HookFuncs is a Hook calling its non-nil functions.
+type HookFuncs struct {
+	Before func(call *APICall)
+	After  func(call *APICall)
+}
+
+// BeforeRequest calls h.Before if it is not nil.
+func (h HookFuncs) BeforeRequest(call *APICall) {
+	if h.Before != nil {
+		h.Before(call)
+	}
+}
+
+// AfterResponse calls h.After if it is not nil.
+func (h HookFuncs) AfterResponse(call *APICall) {
+	if h.After != nil {
+		h.After(call)
+	}
+}
+
+// AddHook adds a hook notified of every API call made by the client. Hooks are
+// called in the order they were added. AddHook must not be called while the
+// client is in use.
+func (c *Client) AddHook(h Hook) {
+	c.hooks = append(c.hooks, h)
+}
+
+// startCall notifies hooks that the request of a call is about to be sent.
+func (c *Client) startCall(call *APICall, params map[string][]string) {
+	call.Start = time.Now()
+	if len(c.hooks) == 0 {
+		return
+	}
+
+	call.Params = make(map[string][]string, len(params))
+	for k, v := range params {
+		switch k {
+		case "apiKey", "apiSig":
+			v = []string{redacted}
+		}
+		call.Params[k] = v
+	}
+
+	for _, h := range c.hooks {
+		h.BeforeRequest(call)
+	}
+}
+
+// finishCall notifies hooks that a call is done. Calls that failed before their
+// request was sent are not reported.
+func (c *Client) finishCall(call *APICall, err error) {
+	if call.Start.IsZero() {
+		return
+	}
+
+	call.Duration = time.Since(call.Start)
+	call.Err = err
+	for _, h := range c.hooks {
+		h.AfterResponse(call)
+	}
+}
+
+// countingReader counts the bytes read from a response body.
+type countingReader struct {
+	io.ReadCloser
+	n *int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	*r.n += int64(n)
+	return n, err
+}
+
+// Logger is the subset of the log/slog.Logger API used by NewLogHook; a
+// *slog.Logger satisfies it. args are alternating keys and values.
+type Logger interface {
+	Info(msg string, args ...interface{})
+	Error(msg string, args ...interface{})
+}
+
+// NewLogHook returns a Hook logging every API call once done to l, at the
+// error level for failed calls and at the info level otherwise.
+func NewLogHook(l Logger) Hook {
+	return HookFuncs{After: func(call *APICall) {
+		args := []interface{}{
+			"method", call.Method,
+			"params", call.Params,
+			"duration", call.Duration,
+			"http_status", call.HTTPStatus,
+			"status", call.Status,
+			"bytes", call.Bytes,
+		}
+
+		if call.Err != nil {
+			args = append(args, "comment", call.Comment, "error", call.Err)
+			l.Error("codeforces api call failed", args...)
+			return
+		}
+		l.Info("codeforces api call", args...)
+	}}
+}
+
+// Tracer starts spans. Together with Span, it mirrors the subset of the
+// OpenTelemetry tracing API used by NewTraceHook, so that a thin adapter is
+// enough to plug in any tracing library.
+type Tracer interface {
+	StartSpan(name string) Span
+}
+
+// Span is a traced operation started by a Tracer.
+type Span interface {
+	SetAttribute(key string, value interface{})
+	RecordError(err error)
+	End()
+}
+
+// traceHook is the Hook returned by NewTraceHook.
+type traceHook struct {
+	tracer Tracer
+}
+
+// NewTraceHook returns a Hook recording a span named "codeforces.<method>" for
+// every API call, with the parameters, HTTP status, API status, comment and
+// response size as attributes.
+func NewTraceHook(t Tracer) Hook {
+	return &traceHook{tracer: t}
+}
+
+func (h *traceHook) BeforeRequest(call *APICall) {
+	span := h.tracer.StartSpan("codeforces." + call.Method)
+	span.SetAttribute("codeforces.method", call.Method)
+	for k, v := range call.Params {
+		span.SetAttribute("codeforces.param."+k, v)
+	}
+	call.SetValue(h, span)
+}
+
+func (h *traceHook) AfterResponse(call *APICall) {
+	span, ok := call.Value(h).(Span)
+	if !ok {
+		return
+	}
+
+	span.SetAttribute("http.status_code", call.HTTPStatus)
+	span.SetAttribute("codeforces.status", call.Status)
+	span.SetAttribute("codeforces.response_bytes", call.Bytes)
+	if call.Comment != "" {
+		span.SetAttribute("codeforces.comment", call.Comment)
+	}
+	if call.Err != nil {
+		span.RecordError(call.Err)
+	}
+	span.End()
+}
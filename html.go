@@ -0,0 +1,235 @@
+package codeforces
+
+import (
+	"html"
+	"strings"
+)
+
+// htmlNode is a node of a parsed HTML fragment. Element nodes have a tag,
+// text nodes have an empty tag and unescaped text.
+type htmlNode struct {
+	tag      string
+	attrs    map[string]string
+	text     string
+	children []*htmlNode
+	parent   *htmlNode
+}
+
+// voidElements are the elements that have no end tag.
+var voidElements = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true, "hr": true, "img": true,
+	"input": true, "link": true, "meta": true, "source": true, "track": true, "wbr": true,
+}
+
+// rawTextElements are the elements whose content is not parsed as HTML.
+var rawTextElements = map[string]bool{
+	"script": true, "style": true, "textarea": true, "title": true,
+}
+
+func (n *htmlNode) attr(name string) string {
+	return n.attrs[name]
+}
+
+// hasClass reports whether the class attribute of n contains class.
+func (n *htmlNode) hasClass(class string) bool {
+	for _, c := range strings.Fields(n.attrs["class"]) {
+		if c == class {
+			return true
+		}
+	}
+	return false
+}
+
+func (n *htmlNode) appendChild(child *htmlNode) {
+	child.parent = n
+	n.children = append(n.children, child)
+}
+
+// textContent returns the concatenated text of the descendants of n, with <br>
+// elements as newlines.
+func (n *htmlNode) textContent() string {
+	var b strings.Builder
+	var walk func(n *htmlNode)
+	walk = func(n *htmlNode) {
+		switch n.tag {
+		case "":
+			b.WriteString(n.text)
+		case "br":
+			b.WriteByte('\n')
+		}
+		for _, c := range n.children {
+			walk(c)
+		}
+	}
+	walk(n)
+	return b.String()
+}
+
+// parseHTML parses an HTML fragment into a tree rooted at a node with an empty
+// tag and no text. The parser is lenient: end tags without a matching start
+// tag are ignored and elements left open are closed at the end of the input.
+// Comments, doctypes and processing instructions are dropped.
+func parseHTML(s string) *htmlNode {
+	root := &htmlNode{}
+	cur := root
+
+	for len(s) > 0 {
+		i := strings.IndexByte(s, '<')
+		if i < 0 {
+			cur.appendChild(&htmlNode{text: html.UnescapeString(s)})
+			break
+		}
+		if i > 0 {
+			cur.appendChild(&htmlNode{text: html.UnescapeString(s[:i])})
+			s = s[i:]
+		}
+
+		switch {
+		case strings.HasPrefix(s, "<!--"):
+			end := strings.Index(s[4:], "-->")
+			if end < 0 {
+				return root
+			}
+			s = s[4+end+3:]
+			continue
+
+		case strings.HasPrefix(s, "<!") || strings.HasPrefix(s, "<?"):
+			end := strings.IndexByte(s, '>')
+			if end < 0 {
+				return root
+			}
+			s = s[end+1:]
+			continue
+		}
+
+		tag, attrs, closing, selfClosing, rest, ok := parseTag(s)
+		if !ok {
+			// A '<' not starting a tag is text.
+			cur.appendChild(&htmlNode{text: "<"})
+			s = s[1:]
+			continue
+		}
+		s = rest
+
+		if closing {
+			for n := cur; n != root; n = n.parent {
+				if n.tag == tag {
+					cur = n.parent
+					break
+				}
+			}
+			continue
+		}
+
+		n := &htmlNode{tag: tag, attrs: attrs}
+		cur.appendChild(n)
+
+		switch {
+		case voidElements[tag] || selfClosing:
+		case rawTextElements[tag]:
+			end := strings.Index(strings.ToLower(s), "</"+tag)
+			if end < 0 {
+				end = len(s)
+			}
+			n.appendChild(&htmlNode{text: s[:end]})
+			s = s[end:]
+			if gt := strings.IndexByte(s, '>'); gt >= 0 {
+				s = s[gt+1:]
+			}
+		default:
+			cur = n
+		}
+	}
+
+	return root
+}
+
+// parseTag parses the start or end tag at the beginning of s, which starts
+// with '<', and returns the remaining input.
+func parseTag(s string) (tag string, attrs map[string]string, closing, selfClosing bool, rest string, ok bool) {
+	i := 1
+	if i < len(s) && s[i] == '/' {
+		closing = true
+		i++
+	}
+
+	start := i
+	for i < len(s) && isTagNameChar(s[i]) {
+		i++
+	}
+	if i == start || !isLetter(s[start]) {
+		return "", nil, false, false, s, false
+	}
+	tag = strings.ToLower(s[start:i])
+
+	attrs = make(map[string]string)
+	for {
+		for i < len(s) && isSpace(s[i]) {
+			i++
+		}
+		if i >= len(s) {
+			return tag, attrs, closing, selfClosing, "", true
+		}
+
+		switch s[i] {
+		case '>':
+			return tag, attrs, closing, selfClosing, s[i+1:], true
+		case '/':
+			selfClosing = true
+			i++
+			continue
+		}
+
+		start := i
+		for i < len(s) && !isSpace(s[i]) && s[i] != '=' && s[i] != '>' && s[i] != '/' {
+			i++
+		}
+		name := strings.ToLower(s[start:i])
+		if name == "" {
+			i++
+			continue
+		}
+
+		for i < len(s) && isSpace(s[i]) {
+			i++
+		}
+		if i >= len(s) || s[i] != '=' {
+			attrs[name] = ""
+			continue
+		}
+		i++
+		for i < len(s) && isSpace(s[i]) {
+			i++
+		}
+
+		var value string
+		if i < len(s) && (s[i] == '"' || s[i] == '\'') {
+			quote := s[i]
+			end := strings.IndexByte(s[i+1:], quote)
+			if end < 0 {
+				value, i = s[i+1:], len(s)
+			} else {
+				value, i = s[i+1:i+1+end], i+1+end+1
+			}
+		} else {
+			start := i
+			for i < len(s) && !isSpace(s[i]) && s[i] != '>' {
+				i++
+			}
+			value = s[start:i]
+		}
+		attrs[name] = html.UnescapeString(value)
+	}
+}
+
+func isLetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func isTagNameChar(c byte) bool {
+	return isLetter(c) || c >= '0' && c <= '9' || c == '-'
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
@@ -0,0 +1,239 @@
+package codeforces
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// leaderboardUserInfoBatchSize is the number of handles per user.info call
+// made by GetLeaderboard.
+const leaderboardUserInfoBatchSize = 500
+
+// LeaderboardRow is the row of a user in a leaderboard.
+type LeaderboardRow struct {
+	Handle       string `json:"handle"`
+	Rank         string `json:"rank"`
+	Rating       int    `json:"rating"`
+	MaxRating    int    `json:"maxRating"`
+	Solved       int    `json:"solved"`
+	Contests     int    `json:"contests"`
+	RatingChange int    `json:"ratingChange"`
+}
+
+// Leaderboard is a list of leaderboard rows.
+type Leaderboard []LeaderboardRow
+
+// LeaderboardOptions configures the computation of a leaderboard.
+type LeaderboardOptions struct {
+	// From and To define the period over which problems solved, contests
+	// attended and rating change are counted. Leave From zero to count from
+	// the beginning and To zero to count until now.
+	From time.Time
+	To   time.Time
+
+	// Concurrency is the maximum number of users fetched concurrently. API
+	// calls still honor the rate limit of the client. Defaults to 1.
+	Concurrency int
+}
+
+func (o LeaderboardOptions) contains(seconds int) bool {
+	t := time.Unix(int64(seconds), 0)
+	if !o.From.IsZero() && t.Before(o.From) {
+		return false
+	}
+	if !o.To.IsZero() && !t.Before(o.To) {
+		return false
+	}
+	return true
+}
+
+// GetLeaderboard computes a leaderboard of the given users, combining
+// GetUserInfo, GetUserRating and GetUserStatus. Users are fetched 500 handles
+// per call. Rows are in the order of handles; use Sort to rank them.
+func (c *Client) GetLeaderboard(handles []string, opts LeaderboardOptions) (Leaderboard, error) {
+	var users []User
+	for len(handles) > 0 {
+		n := leaderboardUserInfoBatchSize
+		if n > len(handles) {
+			n = len(handles)
+		}
+
+		batch, err := c.GetUserInfo(handles[:n])
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, batch...)
+		handles = handles[n:]
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	rows := make(Leaderboard, len(users))
+	errs := make([]error, len(users))
+	sem := make(chan struct{}, concurrency)
+
+	var wg sync.WaitGroup
+	for i := range users {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			rows[i], errs[i] = c.leaderboardRow(users[i], opts)
+		}(i)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return rows, nil
+}
+
+// GetLeaderboard computes a leaderboard of the given users.
+//
+// GetLeaderboard is a wrapper around DefaultClient.GetLeaderboard.
+func GetLeaderboard(handles []string, opts LeaderboardOptions) (Leaderboard, error) {
+	return DefaultClient.GetLeaderboard(handles, opts)
+}
+
+// GetOrganizationLeaderboard computes a leaderboard of the rated users whose
+// organization is organization. The rated list is streamed, so only the
+// members of the organization are kept in memory.
+func (c *Client) GetOrganizationLeaderboard(organization string, activeOnly bool, opts LeaderboardOptions) (Leaderboard, error) {
+	var handles []string
+	err := c.StreamUserRatedList(activeOnly, func(user User) error {
+		if user.Organization == organization {
+			handles = append(handles, user.Handle)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(handles) == 0 {
+		return nil, nil
+	}
+
+	return c.GetLeaderboard(handles, opts)
+}
+
+// GetOrganizationLeaderboard computes a leaderboard of the rated users whose
+// organization is organization.
+//
+// GetOrganizationLeaderboard is a wrapper around
+// DefaultClient.GetOrganizationLeaderboard.
+func GetOrganizationLeaderboard(organization string, activeOnly bool, opts LeaderboardOptions) (Leaderboard, error) {
+	return DefaultClient.GetOrganizationLeaderboard(organization, activeOnly, opts)
+}
+
+func (c *Client) leaderboardRow(user User, opts LeaderboardOptions) (LeaderboardRow, error) {
+	row := LeaderboardRow{
+		Handle:    user.Handle,
+		Rank:      user.Rank,
+		Rating:    user.Rating,
+		MaxRating: user.MaxRating,
+	}
+
+	changes, err := c.GetUserRating(user.Handle)
+	if err != nil {
+		return row, err
+	}
+
+	for _, change := range changes {
+		if opts.contains(change.RatingUpdateTimeSeconds) {
+			row.Contests++
+			row.RatingChange += change.NewRating - change.OldRating
+		}
+	}
+
+	submissions, err := c.GetUserStatus(user.Handle, 1, 0)
+	if err != nil {
+		return row, err
+	}
+
+	// A problem counts as solved in the period if its first accepted
+	// submission is in the period.
+	progress := NewProgress(submissions)
+	for _, t := range progress.FirstAccepted {
+		if opts.contains(t) {
+			row.Solved++
+		}
+	}
+
+	return row, nil
+}
+
+// LeaderboardOrder is a sort order for leaderboards.
+type LeaderboardOrder int
+
+// Sort orders accepted by Leaderboard.Sort. All orders are decreasing.
+const (
+	LeaderboardByRating LeaderboardOrder = iota
+	LeaderboardBySolved
+	LeaderboardByContests
+	LeaderboardByRatingChange
+)
+
+// Sort sorts the leaderboard in decreasing order. Ties are broken by handle.
+func (l Leaderboard) Sort(order LeaderboardOrder) {
+	key := func(row LeaderboardRow) int {
+		switch order {
+		case LeaderboardBySolved:
+			return row.Solved
+		case LeaderboardByContests:
+			return row.Contests
+		case LeaderboardByRatingChange:
+			return row.RatingChange
+		default:
+			return row.Rating
+		}
+	}
+
+	sort.Slice(l, func(i, j int) bool {
+		if key(l[i]) != key(l[j]) {
+			return key(l[i]) > key(l[j])
+		}
+		return l[i].Handle < l[j].Handle
+	})
+}
+
+// WriteJSON writes the leaderboard as a JSON array to w.
+func (l Leaderboard) WriteJSON(w io.Writer) error {
+	return json.NewEncoder(w).Encode(l)
+}
+
+// WriteCSV writes the leaderboard as CSV with a header row to w.
+func (l Leaderboard) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+
+	cw.Write([]string{"handle", "rank", "rating", "maxRating", "solved", "contests", "ratingChange"})
+	for _, row := range l {
+		cw.Write([]string{
+			row.Handle,
+			row.Rank,
+			strconv.Itoa(row.Rating),
+			strconv.Itoa(row.MaxRating),
+			strconv.Itoa(row.Solved),
+			strconv.Itoa(row.Contests),
+			strconv.Itoa(row.RatingChange),
+		})
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
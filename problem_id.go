@@ -0,0 +1,157 @@
+package codeforces
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ProblemID identifies a problem, either by ContestID and Index or, for
+// problems of a non-contest problemset, by ProblemsetName and Index.
+type ProblemID struct {
+	ContestID      int
+	ProblemsetName string
+	Index          string
+}
+
+// ErrInvalidProblemID is returned by ParseProblemID for malformed problem IDs.
+var ErrInvalidProblemID = errors.New("codeforces: invalid problem id")
+
+var (
+	problemIDRegexp    = regexp.MustCompile(`^(\d+)/?([A-Za-z]+\d*)$`)
+	gymProblemIDRegexp = regexp.MustCompile(`^(?:gym|contest)/(\d+)/([A-Za-z]+\d*)$`)
+	problemsetIDRegexp = regexp.MustCompile(`^([a-z]+)/(\w+)$`)
+)
+
+// reservedProblemsetNames are the first path segments of Codeforces URLs that
+// are not problemset names, such as in "gym/102021" or "problemset/1352".
+var reservedProblemsetNames = map[string]bool{
+	"gym": true, "contest": true, "problemset": true, "problemsets": true,
+}
+
+// ParseProblemID parses a problem ID in one of the forms "1352A", "1352/A",
+// "gym/102021/B" or "acmsguru/100".
+func ParseProblemID(s string) (ProblemID, error) {
+	if m := problemIDRegexp.FindStringSubmatch(s); m != nil {
+		contestID, err := strconv.Atoi(m[1])
+		if err != nil {
+			return ProblemID{}, ErrInvalidProblemID
+		}
+		return ProblemID{ContestID: contestID, Index: strings.ToUpper(m[2])}, nil
+	}
+
+	if m := gymProblemIDRegexp.FindStringSubmatch(s); m != nil {
+		contestID, err := strconv.Atoi(m[1])
+		if err != nil {
+			return ProblemID{}, ErrInvalidProblemID
+		}
+		return ProblemID{ContestID: contestID, Index: strings.ToUpper(m[2])}, nil
+	}
+
+	if m := problemsetIDRegexp.FindStringSubmatch(s); m != nil && !reservedProblemsetNames[m[1]] {
+		return ProblemID{ProblemsetName: m[1], Index: m[2]}, nil
+	}
+
+	return ProblemID{}, ErrInvalidProblemID
+}
+
+// ProblemID returns the ID of the problem.
+func (p Problem) ProblemID() ProblemID {
+	if p.ContestID == 0 {
+		return ProblemID{ProblemsetName: p.ProblemsetName, Index: p.Index}
+	}
+	return ProblemID{ContestID: p.ContestID, Index: p.Index}
+}
+
+// String formats the problem ID in the form accepted by ParseProblemID: "1352A"
+// for contest problems, "gym/102021/B" for gym problems and "acmsguru/100" for
+// problemset problems.
+func (p ProblemID) String() string {
+	switch {
+	case p.ContestID == 0:
+		return p.ProblemsetName + "/" + p.Index
+	case isGym(p.ContestID):
+		return fmt.Sprintf("gym/%d/%s", p.ContestID, p.Index)
+	default:
+		return strconv.Itoa(p.ContestID) + p.Index
+	}
+}
+
+// Compare returns -1, 0 or +1 depending on whether p sorts before, equal to or
+// after q. Problem IDs are ordered by problemset name, contest ID and index,
+// with indices like "A1" and "B2" ordered by letter and then numerically.
+func (p ProblemID) Compare(q ProblemID) int {
+	if p.ProblemsetName != q.ProblemsetName {
+		if p.ProblemsetName < q.ProblemsetName {
+			return -1
+		}
+		return 1
+	}
+
+	if p.ContestID != q.ContestID {
+		if p.ContestID < q.ContestID {
+			return -1
+		}
+		return 1
+	}
+
+	return compareProblemIndex(p.Index, q.Index)
+}
+
+// Less reports whether p sorts before q.
+func (p ProblemID) Less(q ProblemID) bool {
+	return p.Compare(q) < 0
+}
+
+// SortProblemIDs sorts ids in increasing order.
+func SortProblemIDs(ids []ProblemID) {
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i].Less(ids[j])
+	})
+}
+
+// compareProblemIndex compares problem indices made of letters followed by an
+// optional number, such as "A", "B2" or "100".
+func compareProblemIndex(a, b string) int {
+	aLetters, aNumber := splitProblemIndex(a)
+	bLetters, bNumber := splitProblemIndex(b)
+
+	if len(aLetters) != len(bLetters) {
+		if len(aLetters) < len(bLetters) {
+			return -1
+		}
+		return 1
+	}
+	if aLetters != bLetters {
+		if aLetters < bLetters {
+			return -1
+		}
+		return 1
+	}
+
+	if aNumber != bNumber {
+		if aNumber < bNumber {
+			return -1
+		}
+		return 1
+	}
+
+	return strings.Compare(a, b)
+}
+
+func splitProblemIndex(index string) (string, int) {
+	i := strings.IndexAny(index, "0123456789")
+	if i < 0 {
+		return index, -1
+	}
+
+	number, err := strconv.Atoi(index[i:])
+	if err != nil {
+		return index, -1
+	}
+
+	return index[:i], number
+}
@@ -0,0 +1,152 @@
+package codeforces
+
+import (
+	"sort"
+)
+
+// Progress summarizes the problems a user has attempted and solved, built from
+// the user's submissions.
+type Progress struct {
+	// Problems contains every problem the user submitted to.
+	Problems map[ProblemID]Problem
+
+	// FirstAccepted maps every solved problem to the creation time of the
+	// first accepted submission.
+	FirstAccepted map[ProblemID]int
+
+	// Attempts maps every problem to the number of submissions made to it.
+	Attempts map[ProblemID]int
+
+	// Contests maps the ID of every contest the user took part in, either as
+	// a contestant, out of competition or virtually, to the participant type.
+	Contests map[int]string
+}
+
+// NewProgress builds the progress of a user from the user's submissions, as
+// returned by GetUserStatus.
+func NewProgress(submissions []Submission) *Progress {
+	p := &Progress{
+		Problems:      make(map[ProblemID]Problem),
+		FirstAccepted: make(map[ProblemID]int),
+		Attempts:      make(map[ProblemID]int),
+		Contests:      make(map[int]string),
+	}
+
+	for _, submission := range submissions {
+		id := submission.Problem.ProblemID()
+
+		p.Problems[id] = submission.Problem
+		p.Attempts[id]++
+
+		if submission.Verdict == "OK" {
+			if t, ok := p.FirstAccepted[id]; !ok || submission.CreationTimeSeconds < t {
+				p.FirstAccepted[id] = submission.CreationTimeSeconds
+			}
+		}
+
+		switch submission.Author.ParticipantType {
+		case "CONTESTANT", "OUT_OF_COMPETITION", "VIRTUAL":
+			p.Contests[submission.ContestID] = submission.Author.ParticipantType
+		}
+	}
+
+	return p
+}
+
+// GetProgress returns the progress of a user.
+func (c *Client) GetProgress(handle string) (*Progress, error) {
+	submissions, err := c.GetUserStatus(handle, 1, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewProgress(submissions), nil
+}
+
+// GetProgress returns the progress of a user.
+//
+// GetProgress is a wrapper around DefaultClient.GetProgress.
+func GetProgress(handle string) (*Progress, error) {
+	return DefaultClient.GetProgress(handle)
+}
+
+// IsSolved reports whether the user has solved a problem.
+func (p *Progress) IsSolved(id ProblemID) bool {
+	_, ok := p.FirstAccepted[id]
+	return ok
+}
+
+// Solved returns the solved problems, sorted by ID.
+func (p *Progress) Solved() []Problem {
+	var res []Problem
+	for id := range p.FirstAccepted {
+		res = append(res, p.Problems[id])
+	}
+
+	sortProblems(res)
+	return res
+}
+
+// Unsolved returns the problems the user attempted but never solved, sorted by
+// ID.
+func (p *Progress) Unsolved() []Problem {
+	var res []Problem
+	for id, problem := range p.Problems {
+		if !p.IsSolved(id) {
+			res = append(res, problem)
+		}
+	}
+
+	sortProblems(res)
+	return res
+}
+
+// Upsolve returns the problems of a contest the user took part in that the
+// user never solved. problems are the problems of the contest, as returned by
+// GetContestStandings.
+func (p *Progress) Upsolve(problems []Problem) []Problem {
+	var res []Problem
+	for _, problem := range problems {
+		if _, ok := p.Contests[problem.ContestID]; ok && !p.IsSolved(problem.ProblemID()) {
+			res = append(res, problem)
+		}
+	}
+
+	return res
+}
+
+// GetUpsolveList returns the problems of every contest the user took part in
+// that the user never solved, sorted by ID. The problems of each contest are
+// fetched with GetContestStandings.
+func (c *Client) GetUpsolveList(p *Progress) ([]Problem, error) {
+	contestIDs := make([]int, 0, len(p.Contests))
+	for contestID := range p.Contests {
+		contestIDs = append(contestIDs, contestID)
+	}
+	sort.Ints(contestIDs)
+
+	var res []Problem
+	for _, contestID := range contestIDs {
+		_, problems, _, err := c.GetContestStandings(contestID, 1, 1, nil, 0, false)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, p.Upsolve(problems)...)
+	}
+
+	return res, nil
+}
+
+// GetUpsolveList returns the problems of every contest the user took part in
+// that the user never solved.
+//
+// GetUpsolveList is a wrapper around DefaultClient.GetUpsolveList.
+func GetUpsolveList(p *Progress) ([]Problem, error) {
+	return DefaultClient.GetUpsolveList(p)
+}
+
+func sortProblems(problems []Problem) {
+	sort.Slice(problems, func(i, j int) bool {
+		return problems[i].ProblemID().Less(problems[j].ProblemID())
+	})
+}
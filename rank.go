@@ -0,0 +1,153 @@
+package codeforces
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"time"
+)
+
+// Rank is a Codeforces rank: a title, the lowest rating holding it and its
+// color.
+type Rank struct {
+	Title     string
+	MinRating int
+	Color     string
+}
+
+// Unrated is the rank of users who never took part in a rated contest.
+var Unrated = Rank{Title: "unrated", Color: "#000000"}
+
+// Ranks are the current ranks, in increasing order of rating.
+var Ranks = []Rank{
+	{"newbie", 0, "#808080"},
+	{"pupil", 1200, "#008000"},
+	{"specialist", 1400, "#03a89e"},
+	{"expert", 1600, "#0000ff"},
+	{"candidate master", 1900, "#aa00aa"},
+	{"master", 2100, "#ff8c00"},
+	{"international master", 2300, "#ff8c00"},
+	{"grandmaster", 2400, "#ff0000"},
+	{"international grandmaster", 2600, "#ff0000"},
+	{"legendary grandmaster", 3000, "#ff0000"},
+}
+
+// HistoricalRanks are the ranks used before HistoricalRanksEnd, in increasing
+// order of rating.
+var HistoricalRanks = []Rank{
+	{"newbie", 0, "#808080"},
+	{"pupil", 1200, "#008000"},
+	{"specialist", 1500, "#03a89e"},
+	{"expert", 1700, "#0000ff"},
+	{"candidate master", 1900, "#aa00aa"},
+	{"master", 2200, "#ff8c00"},
+	{"international master", 2300, "#ff8c00"},
+	{"grandmaster", 2400, "#ff0000"},
+	{"international grandmaster", 2600, "#ff0000"},
+	{"legendary grandmaster", 2900, "#ff0000"},
+}
+
+// HistoricalRanksEnd is the time the current rank thresholds replaced
+// HistoricalRanks.
+var HistoricalRanksEnd = time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+func rankIn(ranks []Rank, rating int) Rank {
+	i := len(ranks) - 1
+	for i > 0 && rating < ranks[i].MinRating {
+		i--
+	}
+	return ranks[i]
+}
+
+// RankOf returns the current rank of rating.
+func RankOf(rating int) Rank {
+	return rankIn(Ranks, rating)
+}
+
+// RankAt returns the rank of rating at time t, using HistoricalRanks before
+// HistoricalRanksEnd.
+func RankAt(rating int, t time.Time) Rank {
+	if t.Before(HistoricalRanksEnd) {
+		return rankIn(HistoricalRanks, rating)
+	}
+	return rankIn(Ranks, rating)
+}
+
+// ParseRank returns the rank with the given title, such as User.Rank or
+// User.MaxRank. Titles are matched case-insensitively. Returns Unrated for
+// empty or unknown titles.
+func ParseRank(title string) Rank {
+	title = strings.ToLower(strings.TrimSpace(title))
+	for _, rank := range Ranks {
+		if rank.Title == title {
+			return rank
+		}
+	}
+	return Unrated
+}
+
+// CurrentRank returns the current rank of the user.
+func (u User) CurrentRank() Rank {
+	if u.Rank == "" {
+		return Unrated
+	}
+	return RankOf(u.Rating)
+}
+
+// HighestRank returns the highest rank the user ever reached.
+func (u User) HighestRank() Rank {
+	if u.MaxRank == "" {
+		return Unrated
+	}
+	return RankOf(u.MaxRating)
+}
+
+// String returns the title of the rank.
+func (r Rank) String() string {
+	return r.Title
+}
+
+// isLegendary reports whether handles of the rank have their first letter
+// styled differently.
+func (r Rank) isLegendary() bool {
+	return r.Title == "legendary grandmaster"
+}
+
+// HTML returns handle colored with the color of the rank, as HTML. The first
+// letter of legendary grandmasters is black, like on Codeforces.
+func (r Rank) HTML(handle string) string {
+	if r.isLegendary() && handle != "" {
+		first, rest := splitFirstRune(handle)
+		return fmt.Sprintf(`<span style="font-weight:bold"><span style="color:#000000">%s</span><span style="color:%s">%s</span></span>`,
+			html.EscapeString(first), r.Color, html.EscapeString(rest))
+	}
+
+	return fmt.Sprintf(`<span style="color:%s;font-weight:bold">%s</span>`, r.Color, html.EscapeString(handle))
+}
+
+// ANSI returns handle colored with the color of the rank, using 24-bit ANSI
+// terminal escape codes. The first letter of legendary grandmasters uses the
+// default terminal color.
+func (r Rank) ANSI(handle string) string {
+	if r.isLegendary() && handle != "" {
+		first, rest := splitFirstRune(handle)
+		return "\x1b[1m" + first + ansiColor(r.Color) + rest + "\x1b[0m"
+	}
+
+	return "\x1b[1m" + ansiColor(r.Color) + handle + "\x1b[0m"
+}
+
+func ansiColor(color string) string {
+	var red, green, blue int
+	fmt.Sscanf(color, "#%02x%02x%02x", &red, &green, &blue)
+	return fmt.Sprintf("\x1b[38;2;%d;%d;%dm", red, green, blue)
+}
+
+func splitFirstRune(s string) (string, string) {
+	for i := range s {
+		if i > 0 {
+			return s[:i], s[i:]
+		}
+	}
+	return s, ""
+}
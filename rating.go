@@ -0,0 +1,224 @@
+package codeforces
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"io"
+	"sort"
+	"time"
+)
+
+// RatingHistory is the rating history of a user, in chronological order, as
+// returned by GetUserRating.
+type RatingHistory []RatingChange
+
+// Peak returns the rating change reaching the highest rating. Returns the zero
+// value for an empty history.
+func (h RatingHistory) Peak() RatingChange {
+	var peak RatingChange
+	for i, change := range h {
+		if i == 0 || change.NewRating > peak.NewRating {
+			peak = change
+		}
+	}
+	return peak
+}
+
+// Ranks returns the rank after each rating change, using the rank thresholds
+// in effect at the time of the change.
+func (h RatingHistory) Ranks() []Rank {
+	res := make([]Rank, len(h))
+	for i, change := range h {
+		res[i] = RankAt(change.NewRating, time.Unix(int64(change.RatingUpdateTimeSeconds), 0))
+	}
+	return res
+}
+
+// BiggestGains returns at most n rating changes with the largest rating gains,
+// largest first. Rating losses are never included.
+func (h RatingHistory) BiggestGains(n int) []RatingChange {
+	res := h.sortedByDelta(func(a, b int) bool { return a > b })
+	for i, change := range res {
+		if change.NewRating <= change.OldRating {
+			res = res[:i]
+			break
+		}
+	}
+	if len(res) > n {
+		res = res[:n]
+	}
+	return res
+}
+
+// BiggestLosses returns at most n rating changes with the largest rating
+// losses, largest first. Rating gains are never included.
+func (h RatingHistory) BiggestLosses(n int) []RatingChange {
+	res := h.sortedByDelta(func(a, b int) bool { return a < b })
+	for i, change := range res {
+		if change.NewRating >= change.OldRating {
+			res = res[:i]
+			break
+		}
+	}
+	if len(res) > n {
+		res = res[:n]
+	}
+	return res
+}
+
+func (h RatingHistory) sortedByDelta(less func(a, b int) bool) []RatingChange {
+	res := make([]RatingChange, len(h))
+	copy(res, h)
+
+	sort.SliceStable(res, func(i, j int) bool {
+		return less(res[i].NewRating-res[i].OldRating, res[j].NewRating-res[j].OldRating)
+	})
+
+	return res
+}
+
+// ContestsPerDivision returns the number of rated contests per division, as
+// detected from the contest names by ContestDivision.
+func (h RatingHistory) ContestsPerDivision() map[Division]int {
+	res := make(map[Division]int)
+	for _, change := range h {
+		res[ContestDivision(change.ContestName)]++
+	}
+	return res
+}
+
+// TimeAtRank returns the time spent at each rank title, until now for the
+// current rank.
+func (h RatingHistory) TimeAtRank(now time.Time) map[string]time.Duration {
+	res := make(map[string]time.Duration)
+	ranks := h.Ranks()
+
+	for i, change := range h {
+		start := time.Unix(int64(change.RatingUpdateTimeSeconds), 0)
+		end := now
+		if i+1 < len(h) {
+			end = time.Unix(int64(h[i+1].RatingUpdateTimeSeconds), 0)
+		}
+		res[ranks[i].Title] += end.Sub(start)
+	}
+
+	return res
+}
+
+// RatingSeries is the rating history of a user to plot on a rating chart.
+type RatingSeries struct {
+	Handle  string
+	History RatingHistory
+}
+
+var ratingChartColors = []string{"#1f77b4", "#d62728", "#2ca02c", "#9467bd", "#8c564b", "#e377c2", "#17becf", "#bcbd22"}
+
+const (
+	ratingChartWidth  = 800
+	ratingChartHeight = 400
+	ratingChartMargin = 40
+)
+
+// WriteRatingChartSVG writes a chart of the rating histories of one or several
+// users as a SVG image to w, on top of the colored bands of the current ranks.
+func WriteRatingChartSVG(w io.Writer, series []RatingSeries) error {
+	minTime, maxTime := 0, 0
+	minRating, maxRating := 0, 0
+	first := true
+	for _, s := range series {
+		for _, change := range s.History {
+			if first {
+				minTime, maxTime = change.RatingUpdateTimeSeconds, change.RatingUpdateTimeSeconds
+				minRating, maxRating = change.NewRating, change.NewRating
+				first = false
+			}
+			if change.RatingUpdateTimeSeconds < minTime {
+				minTime = change.RatingUpdateTimeSeconds
+			}
+			if change.RatingUpdateTimeSeconds > maxTime {
+				maxTime = change.RatingUpdateTimeSeconds
+			}
+			if change.NewRating < minRating {
+				minRating = change.NewRating
+			}
+			if change.NewRating > maxRating {
+				maxRating = change.NewRating
+			}
+		}
+	}
+
+	minRating = (minRating - 100) / 100 * 100
+	if minRating < 0 {
+		minRating = 0
+	}
+	maxRating = (maxRating + 199) / 100 * 100
+	if maxTime == minTime {
+		maxTime = minTime + 1
+	}
+
+	plotWidth := float64(ratingChartWidth - 2*ratingChartMargin)
+	plotHeight := float64(ratingChartHeight - 2*ratingChartMargin)
+
+	x := func(t int) float64 {
+		return ratingChartMargin + plotWidth*float64(t-minTime)/float64(maxTime-minTime)
+	}
+	y := func(rating int) float64 {
+		return ratingChartMargin + plotHeight*float64(maxRating-rating)/float64(maxRating-minRating)
+	}
+
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintf(bw, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" font-family="sans-serif" font-size="10">`+"\n", ratingChartWidth, ratingChartHeight)
+
+	for i, rank := range Ranks {
+		lo, hi := rank.MinRating, maxRating
+		if i+1 < len(Ranks) {
+			hi = Ranks[i+1].MinRating
+		}
+		if lo < minRating {
+			lo = minRating
+		}
+		if hi > maxRating {
+			hi = maxRating
+		}
+		if lo >= hi {
+			continue
+		}
+
+		fmt.Fprintf(bw, `<rect x="%d" y="%.1f" width="%.1f" height="%.1f" fill="%s" fill-opacity="0.25"><title>%s</title></rect>`+"\n",
+			ratingChartMargin, y(hi), plotWidth, y(lo)-y(hi), rank.Color, rank.Title)
+		fmt.Fprintf(bw, `<text x="%d" y="%.1f" text-anchor="end">%d</text>`+"\n", ratingChartMargin-4, y(lo)+3, lo)
+	}
+
+	for i, s := range series {
+		color := ratingChartColors[i%len(ratingChartColors)]
+
+		fmt.Fprintf(bw, `<polyline fill="none" stroke="%s" stroke-width="1.5" points="`, color)
+		for j, change := range s.History {
+			if j > 0 {
+				bw.WriteString(" ")
+			}
+			fmt.Fprintf(bw, "%.1f,%.1f", x(change.RatingUpdateTimeSeconds), y(change.NewRating))
+		}
+		bw.WriteString(`"/>` + "\n")
+
+		for _, change := range s.History {
+			fmt.Fprintf(bw, `<circle cx="%.1f" cy="%.1f" r="2.5" fill="%s"><title>%s: %d (%s)</title></circle>`+"\n",
+				x(change.RatingUpdateTimeSeconds), y(change.NewRating), color,
+				html.EscapeString(s.Handle), change.NewRating, html.EscapeString(change.ContestName))
+		}
+
+		fmt.Fprintf(bw, `<text x="%d" y="%d" fill="%s">%s</text>`+"\n",
+			ratingChartMargin+4+100*i, ratingChartMargin-8, color, html.EscapeString(s.Handle))
+	}
+
+	for _, t := range []int{minTime, maxTime} {
+		fmt.Fprintf(bw, `<text x="%.1f" y="%d" text-anchor="middle">%s</text>`+"\n",
+			x(t), ratingChartHeight-ratingChartMargin+14, time.Unix(int64(t), 0).UTC().Format("2006-01-02"))
+	}
+
+	fmt.Fprintln(bw, "</svg>")
+
+	return bw.Flush()
+}
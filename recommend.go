@@ -0,0 +1,205 @@
+package codeforces
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+)
+
+// RecommendOptions configures the problems recommended by Recommend.
+type RecommendOptions struct {
+	// MinRatingDelta and MaxRatingDelta define the window of problem ratings
+	// recommended, relative to the rating of the user, e.g. -100 and 300.
+	MinRatingDelta int
+	MaxRatingDelta int
+
+	// Count is the maximum number of recommendations.
+	Count int
+
+	// Seed seeds the random choice of problems. The same seed and inputs
+	// always produce the same recommendations.
+	Seed int64
+}
+
+// DefaultRecommendOptions are sensible options for Recommend and
+// GetRecommendations.
+var DefaultRecommendOptions = RecommendOptions{
+	MinRatingDelta: -100,
+	MaxRatingDelta: 300,
+	Count:          10,
+}
+
+// Recommendation is a problem recommended for practice.
+type Recommendation struct {
+	Problem     Problem
+	SolvedCount int
+
+	// WeakTags are the tags of the problem with a low acceptance ratio in the
+	// history of the user.
+	WeakTags []string
+
+	// Weight is the weight the problem was chosen with; higher weights are
+	// more likely to be recommended.
+	Weight float64
+
+	// Reason explains why the problem was recommended.
+	Reason string
+}
+
+// unratedRating is the rating assumed for users without a rating.
+const unratedRating = 800
+
+// weakTagAcceptance is the acceptance ratio under which a tag is considered
+// weak.
+const weakTagAcceptance = 0.5
+
+// TagAcceptance returns, for every tag of an attempted problem, the ratio of
+// solved problems to submissions.
+func (p *Progress) TagAcceptance() map[string]float64 {
+	attempts := make(map[string]int)
+	solved := make(map[string]int)
+
+	for id, problem := range p.Problems {
+		for _, tag := range problem.Tags {
+			attempts[tag] += p.Attempts[id]
+			if p.IsSolved(id) {
+				solved[tag]++
+			}
+		}
+	}
+
+	res := make(map[string]float64, len(attempts))
+	for tag, n := range attempts {
+		res[tag] = float64(solved[tag]) / float64(n)
+	}
+
+	return res
+}
+
+// Recommend recommends unsolved problems among problems for a user with the
+// given rating and progress. Problems are chosen at random within the rating
+// window, weighted toward problems with weak tags and problems solved by many
+// users.
+//
+// stats are the statistics of problems, as returned by GetProblemsetProblems.
+func Recommend(problems []Problem, stats []ProblemStatistics, progress *Progress, rating int, opts RecommendOptions) []Recommendation {
+	if rating == 0 {
+		rating = unratedRating
+	}
+	minRating, maxRating := rating+opts.MinRatingDelta, rating+opts.MaxRatingDelta
+
+	solvedCount := make(map[ProblemID]int, len(stats))
+	for _, s := range stats {
+		solvedCount[ProblemID{ContestID: s.ContestID, Index: s.Index}] = s.SolvedCount
+	}
+
+	acceptance := progress.TagAcceptance()
+
+	var candidates []Recommendation
+	for _, problem := range problems {
+		id := problem.ProblemID()
+		if problem.Rating == 0 || problem.Rating < minRating || problem.Rating > maxRating || progress.IsSolved(id) {
+			continue
+		}
+
+		weight := 1.0
+		var weakTags []string
+		for _, tag := range problem.Tags {
+			ratio, ok := acceptance[tag]
+			if ok && ratio < weakTagAcceptance {
+				weight += 1 - ratio
+				weakTags = append(weakTags, tag)
+			}
+		}
+		weight *= math.Log(2 + float64(solvedCount[id]))
+
+		candidates = append(candidates, Recommendation{
+			Problem:     problem,
+			SolvedCount: solvedCount[id],
+			WeakTags:    weakTags,
+			Weight:      weight,
+			Reason:      recommendationReason(problem, rating, weakTags, acceptance, solvedCount[id]),
+		})
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].Problem.ProblemID().Less(candidates[j].Problem.ProblemID())
+	})
+
+	// Weighted sampling without replacement: each candidate gets the key
+	// u^(1/weight) for a uniform u, and the largest keys are chosen.
+	r := rand.New(rand.NewSource(opts.Seed))
+	keys := make([]float64, len(candidates))
+	for i := range candidates {
+		keys[i] = math.Pow(r.Float64(), 1/candidates[i].Weight)
+	}
+
+	order := make([]int, len(candidates))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return keys[order[i]] > keys[order[j]]
+	})
+
+	if opts.Count > 0 && len(order) > opts.Count {
+		order = order[:opts.Count]
+	}
+
+	res := make([]Recommendation, len(order))
+	for i, j := range order {
+		res[i] = candidates[j]
+	}
+
+	return res
+}
+
+func recommendationReason(problem Problem, rating int, weakTags []string, acceptance map[string]float64, solvedCount int) string {
+	reason := fmt.Sprintf("rated %d for your rating %d", problem.Rating, rating)
+
+	if len(weakTags) > 0 {
+		tags := make([]string, len(weakTags))
+		for i, tag := range weakTags {
+			tags[i] = fmt.Sprintf("%s (%.0f%% acceptance)", tag, 100*acceptance[tag])
+		}
+		reason += "; practices weak tags " + strings.Join(tags, ", ")
+	}
+
+	return reason + fmt.Sprintf("; solved by %d users", solvedCount)
+}
+
+// GetRecommendations recommends unsolved problems from the default problemset
+// for a user, based on the user's rating and submissions.
+func (c *Client) GetRecommendations(handle string, opts RecommendOptions) ([]Recommendation, error) {
+	users, err := c.GetUserInfo([]string{handle})
+	if err != nil {
+		return nil, err
+	}
+
+	progress, err := c.GetProgress(handle)
+	if err != nil {
+		return nil, err
+	}
+
+	problems, stats, err := c.GetProblemsetProblems(nil, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var rating int
+	if len(users) > 0 {
+		rating = users[0].Rating
+	}
+
+	return Recommend(problems, stats, progress, rating, opts), nil
+}
+
+// GetRecommendations recommends unsolved problems from the default problemset
+// for a user.
+//
+// GetRecommendations is a wrapper around DefaultClient.GetRecommendations.
+func GetRecommendations(handle string, opts RecommendOptions) ([]Recommendation, error) {
+	return DefaultClient.GetRecommendations(handle, opts)
+}
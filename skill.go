@@ -0,0 +1,279 @@
+package codeforces
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// SkillProfile summarizes the strengths of a user by tag and by problem
+// rating, computed from the user's submissions.
+type SkillProfile struct {
+	Tags                []SkillStats `json:"tags"`
+	Ratings             []SkillStats `json:"ratings"`
+	HardestSolvedRating int          `json:"hardestSolvedRating"`
+	Languages           []SkillCount `json:"languages"`
+	Verdicts            []SkillCount `json:"verdicts"`
+}
+
+// SkillStats summarizes the problems of a tag or of a rating bucket.
+type SkillStats struct {
+	Name      string `json:"name"`
+	Attempted int    `json:"attempted"`
+	Solved    int    `json:"solved"`
+
+	// FirstTryAcceptance is the ratio of attempted problems accepted on the
+	// first submission.
+	FirstTryAcceptance float64 `json:"firstTryAcceptance"`
+
+	// MedianAttemptsToAC is the median number of submissions needed to solve
+	// the solved problems.
+	MedianAttemptsToAC float64 `json:"medianAttemptsToAc"`
+
+	HardestSolvedRating int `json:"hardestSolvedRating"`
+}
+
+// SkillCount is the number of submissions of a language or of a verdict.
+type SkillCount struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// skillRatingBucket is the width of the rating buckets of a SkillProfile.
+const skillRatingBucket = 100
+
+type problemAttempts struct {
+	problem       Problem
+	attempts      int
+	attemptsToAC  int
+	firstAccepted bool
+}
+
+// NewSkillProfile computes the skill profile of a user from the user's
+// submissions, as returned by GetUserStatus.
+func NewSkillProfile(submissions []Submission) *SkillProfile {
+	sorted := make([]Submission, len(submissions))
+	copy(sorted, submissions)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].CreationTimeSeconds < sorted[j].CreationTimeSeconds
+	})
+
+	languages := make(map[string]int)
+	verdicts := make(map[string]int)
+
+	problems := make(map[ProblemID]*problemAttempts)
+	var order []ProblemID
+
+	for _, submission := range sorted {
+		languages[submission.ProgrammingLanguage]++
+		if submission.Verdict != "" {
+			verdicts[submission.Verdict]++
+		}
+
+		// Like in standings, pending submissions and compilation errors are
+		// not attempts.
+		if isPending(submission) || submission.Verdict == "COMPILATION_ERROR" {
+			continue
+		}
+
+		id := submission.Problem.ProblemID()
+		p, ok := problems[id]
+		if !ok {
+			p = &problemAttempts{problem: submission.Problem}
+			problems[id] = p
+			order = append(order, id)
+		}
+
+		if p.attemptsToAC != 0 {
+			continue
+		}
+
+		p.attempts++
+		if submission.Verdict == "OK" {
+			p.attemptsToAC = p.attempts
+			p.firstAccepted = p.attempts == 1
+		}
+	}
+
+	tags := make(map[string][]*problemAttempts)
+	ratings := make(map[int][]*problemAttempts)
+
+	profile := &SkillProfile{
+		Languages: sortedCounts(languages),
+		Verdicts:  sortedCounts(verdicts),
+	}
+
+	for _, id := range order {
+		p := problems[id]
+
+		for _, tag := range p.problem.Tags {
+			tags[tag] = append(tags[tag], p)
+		}
+
+		if p.problem.Rating != 0 {
+			bucket := p.problem.Rating / skillRatingBucket * skillRatingBucket
+			ratings[bucket] = append(ratings[bucket], p)
+
+			if p.attemptsToAC != 0 && p.problem.Rating > profile.HardestSolvedRating {
+				profile.HardestSolvedRating = p.problem.Rating
+			}
+		}
+	}
+
+	for tag, attempts := range tags {
+		profile.Tags = append(profile.Tags, newSkillStats(tag, attempts))
+	}
+	sort.Slice(profile.Tags, func(i, j int) bool {
+		if profile.Tags[i].Attempted != profile.Tags[j].Attempted {
+			return profile.Tags[i].Attempted > profile.Tags[j].Attempted
+		}
+		return profile.Tags[i].Name < profile.Tags[j].Name
+	})
+
+	buckets := make([]int, 0, len(ratings))
+	for bucket := range ratings {
+		buckets = append(buckets, bucket)
+	}
+	sort.Ints(buckets)
+
+	for _, bucket := range buckets {
+		profile.Ratings = append(profile.Ratings, newSkillStats(strconv.Itoa(bucket), ratings[bucket]))
+	}
+
+	return profile
+}
+
+func newSkillStats(name string, problems []*problemAttempts) SkillStats {
+	stats := SkillStats{Name: name, Attempted: len(problems)}
+
+	var firstTry int
+	var attemptsToAC []int
+	for _, p := range problems {
+		if p.attemptsToAC == 0 {
+			continue
+		}
+
+		stats.Solved++
+		attemptsToAC = append(attemptsToAC, p.attemptsToAC)
+		if p.firstAccepted {
+			firstTry++
+		}
+		if p.problem.Rating > stats.HardestSolvedRating {
+			stats.HardestSolvedRating = p.problem.Rating
+		}
+	}
+
+	if stats.Attempted > 0 {
+		stats.FirstTryAcceptance = float64(firstTry) / float64(stats.Attempted)
+	}
+	stats.MedianAttemptsToAC = median(attemptsToAC)
+
+	return stats
+}
+
+func median(values []int) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	sorted := make([]int, len(values))
+	copy(sorted, values)
+	sort.Ints(sorted)
+
+	n := len(sorted)
+	if n%2 == 1 {
+		return float64(sorted[n/2])
+	}
+	return float64(sorted[n/2-1]+sorted[n/2]) / 2
+}
+
+// sortedCounts converts counters to a list sorted by decreasing count.
+func sortedCounts(counts map[string]int) []SkillCount {
+	res := make([]SkillCount, 0, len(counts))
+	for name, count := range counts {
+		res = append(res, SkillCount{Name: name, Count: count})
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Count != res[j].Count {
+			return res[i].Count > res[j].Count
+		}
+		return res[i].Name < res[j].Name
+	})
+
+	return res
+}
+
+// GetSkillProfile returns the skill profile of a user.
+func (c *Client) GetSkillProfile(handle string) (*SkillProfile, error) {
+	submissions, err := c.GetUserStatus(handle, 1, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSkillProfile(submissions), nil
+}
+
+// GetSkillProfile returns the skill profile of a user.
+//
+// GetSkillProfile is a wrapper around DefaultClient.GetSkillProfile.
+func GetSkillProfile(handle string) (*SkillProfile, error) {
+	return DefaultClient.GetSkillProfile(handle)
+}
+
+// WriteJSON writes the skill profile as indented JSON to w.
+func (p *SkillProfile) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(p)
+}
+
+// markdownCellEscaper escapes text for a cell of a Markdown table.
+var markdownCellEscaper = strings.NewReplacer(`\`, `\\`, "|", `\|`, "\n", " ")
+
+// WriteMarkdown writes the skill profile as Markdown tables to w.
+func (p *SkillProfile) WriteMarkdown(w io.Writer) error {
+	var err error
+	printf := func(format string, a ...interface{}) {
+		if err == nil {
+			_, err = fmt.Fprintf(w, format, a...)
+		}
+	}
+
+	printf("Hardest solved rating: %d\n", p.HardestSolvedRating)
+
+	for _, section := range []struct {
+		title string
+		stats []SkillStats
+	}{
+		{"Tags", p.Tags},
+		{"Ratings", p.Ratings},
+	} {
+		printf("\n## %s\n\n", section.title)
+		printf("| %s | Attempted | Solved | First try | Median attempts | Hardest solved |\n", section.title[:len(section.title)-1])
+		printf("|---|---:|---:|---:|---:|---:|\n")
+		for _, s := range section.stats {
+			printf("| %s | %d | %d | %.0f%% | %g | %d |\n", markdownCellEscaper.Replace(s.Name), s.Attempted, s.Solved, 100*s.FirstTryAcceptance, s.MedianAttemptsToAC, s.HardestSolvedRating)
+		}
+	}
+
+	for _, section := range []struct {
+		title  string
+		counts []SkillCount
+	}{
+		{"Languages", p.Languages},
+		{"Verdicts", p.Verdicts},
+	} {
+		printf("\n## %s\n\n", section.title)
+		printf("| %s | Submissions |\n", section.title[:len(section.title)-1])
+		printf("|---|---:|\n")
+		for _, c := range section.counts {
+			printf("| %s | %d |\n", markdownCellEscaper.Replace(c.Name), c.Count)
+		}
+	}
+
+	return err
+}
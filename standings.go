@@ -0,0 +1,385 @@
+package codeforces
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// StandingsCalculator recomputes the standings of a contest from its raw
+// submissions and hacks, following the rules selected by Contest.Type ("CF",
+// "ICPC" or "IOI").
+//
+// Submissions may be added in any order and the ranklist can be requested at
+// any time, which makes it usable on a live stream of submissions as well as
+// on the complete output of GetContestStatus.
+type StandingsCalculator struct {
+	contest        Contest
+	problems       []Problem
+	showUnofficial bool
+
+	// penaltyPerAttempt is the ICPC penalty, in minutes, of each rejected
+	// attempt on a solved problem.
+	penaltyPerAttempt int
+
+	parties map[string]*standingsParty
+}
+
+type standingsParty struct {
+	party       Party
+	submissions [][]Submission
+	hacks       map[int]string
+}
+
+// NewStandingsCalculator creates a new StandingsCalculator for contest.
+//
+// problems must be the problems of the contest in standings order, with Points
+// set for CF and IOI contests. Set showUnofficial to include participants other
+// than CONTESTANT, like in GetContestStandings.
+//
+// Under ICPC rules, each rejected attempt on a solved problem costs 10 minutes
+// of penalty in Educational, Div. 3 and Div. 4 rounds, as told by the name of
+// the contest, and 20 minutes otherwise. Use SetPenaltyPerAttempt to override
+// it.
+func NewStandingsCalculator(contest Contest, problems []Problem, showUnofficial bool) *StandingsCalculator {
+	return &StandingsCalculator{
+		contest:           contest,
+		problems:          problems,
+		showUnofficial:    showUnofficial,
+		penaltyPerAttempt: defaultPenaltyPerAttempt(contest),
+		parties:           make(map[string]*standingsParty),
+	}
+}
+
+// defaultPenaltyPerAttempt returns the ICPC penalty, in minutes, of a rejected
+// attempt in contest.
+func defaultPenaltyPerAttempt(contest Contest) int {
+	name := strings.ToLower(contest.Name)
+	for _, s := range []string{"educational", "div. 3", "div. 4"} {
+		if strings.Contains(name, s) {
+			return 10
+		}
+	}
+	return 20
+}
+
+// SetPenaltyPerAttempt sets the penalty, in minutes, of each rejected attempt
+// on a solved problem under ICPC rules.
+func (s *StandingsCalculator) SetPenaltyPerAttempt(minutes int) {
+	s.penaltyPerAttempt = minutes
+}
+
+// AddSubmission adds a submission to the standings. Submissions made outside
+// of the contest (practice or after the end of the contest) are ignored.
+func (s *StandingsCalculator) AddSubmission(submission Submission) {
+	if !s.includeParty(submission.Author) {
+		return
+	}
+	if submission.RelativeTimeSeconds < 0 || submission.RelativeTimeSeconds > s.contest.DurationSeconds {
+		return
+	}
+
+	problemIndex := s.problemIndex(submission.Problem.Index)
+	if problemIndex < 0 {
+		return
+	}
+
+	p := s.party(submission.Author)
+	p.submissions[problemIndex] = append(p.submissions[problemIndex], submission)
+}
+
+// AddHack adds a hack to the standings. Hacks only affect the points of CF
+// contests.
+func (s *StandingsCalculator) AddHack(hack Hack) {
+	if !s.includeParty(hack.Hacker) {
+		return
+	}
+
+	p := s.party(hack.Hacker)
+	p.hacks[hack.ID] = hack.Verdict
+}
+
+// Rows returns the ranklist computed from the submissions and hacks added so
+// far. Parties with equal results share the same rank.
+func (s *StandingsCalculator) Rows() []RanklistRow {
+	rows := make([]RanklistRow, 0, len(s.parties))
+	keys := make([]string, 0, len(s.parties))
+
+	for key, p := range s.parties {
+		rows = append(rows, s.row(p))
+		keys = append(keys, key)
+	}
+
+	sort.Sort(&ranklistSorter{rows: rows, keys: keys, byPenalty: s.contest.Type == "ICPC"})
+
+	for i := range rows {
+		if i > 0 && rows[i].Points == rows[i-1].Points && (s.contest.Type != "ICPC" || rows[i].Penalty == rows[i-1].Penalty) {
+			rows[i].Rank = rows[i-1].Rank
+		} else {
+			rows[i].Rank = i + 1
+		}
+	}
+
+	return rows
+}
+
+// ComputeStandings recomputes the standings of contest from its problems,
+// submissions and hacks, as returned by GetContestStatus and GetContestHacks.
+// hacks may be nil for contests without hacks.
+//
+// ComputeStandings is a shorthand for creating a StandingsCalculator, adding
+// all submissions and hacks to it and calling Rows.
+func ComputeStandings(contest Contest, problems []Problem, submissions []Submission, hacks []Hack, showUnofficial bool) []RanklistRow {
+	s := NewStandingsCalculator(contest, problems, showUnofficial)
+	for _, submission := range submissions {
+		s.AddSubmission(submission)
+	}
+	for _, hack := range hacks {
+		s.AddHack(hack)
+	}
+
+	return s.Rows()
+}
+
+func (s *StandingsCalculator) includeParty(party Party) bool {
+	switch party.ParticipantType {
+	case "CONTESTANT":
+		return true
+	case "OUT_OF_COMPETITION", "VIRTUAL":
+		return s.showUnofficial
+	default:
+		return false
+	}
+}
+
+func (s *StandingsCalculator) problemIndex(index string) int {
+	for i, problem := range s.problems {
+		if problem.Index == index {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func (s *StandingsCalculator) party(party Party) *standingsParty {
+	key := partyKey(party)
+
+	p, ok := s.parties[key]
+	if !ok {
+		p = &standingsParty{
+			party:       party,
+			submissions: make([][]Submission, len(s.problems)),
+			hacks:       make(map[int]string),
+		}
+		s.parties[key] = p
+	}
+
+	return p
+}
+
+func (s *StandingsCalculator) row(p *standingsParty) RanklistRow {
+	row := RanklistRow{
+		Party:          p.party,
+		ProblemResults: make([]ProblemResult, len(s.problems)),
+	}
+
+	resultType := "PRELIMINARY"
+	if s.contest.Phase == "FINISHED" {
+		resultType = "FINAL"
+	}
+
+	for i, submissions := range p.submissions {
+		sort.SliceStable(submissions, func(a, b int) bool {
+			if submissions[a].RelativeTimeSeconds != submissions[b].RelativeTimeSeconds {
+				return submissions[a].RelativeTimeSeconds < submissions[b].RelativeTimeSeconds
+			}
+			return submissions[a].ID < submissions[b].ID
+		})
+
+		var result ProblemResult
+		switch s.contest.Type {
+		case "ICPC":
+			result = icpcProblemResult(submissions, s.penaltyPerAttempt)
+		case "IOI":
+			result = ioiProblemResult(s.problems[i], submissions)
+		default:
+			result = cfProblemResult(s.problems[i], s.contest.DurationSeconds, submissions)
+		}
+		result.Type = resultType
+
+		row.ProblemResults[i] = result
+		row.Points += result.Points
+		row.Penalty += result.Penalty
+
+		if s.contest.Type == "IOI" && result.Points > 0 && result.BestSubmissionTimeSeconds > row.LastSubmissionTimeSeconds {
+			row.LastSubmissionTimeSeconds = result.BestSubmissionTimeSeconds
+		}
+	}
+
+	if s.contest.Type == "CF" {
+		for _, verdict := range p.hacks {
+			switch verdict {
+			case "HACK_SUCCESSFUL":
+				row.SuccessfulHackCount++
+			case "HACK_UNSUCCESSFUL":
+				row.UnsuccessfulHackCount++
+			}
+		}
+		row.Points += float64(100*row.SuccessfulHackCount - 50*row.UnsuccessfulHackCount)
+	}
+
+	return row
+}
+
+// isPending reports whether a submission has not been judged yet.
+func isPending(submission Submission) bool {
+	return submission.Verdict == "" || submission.Verdict == "TESTING"
+}
+
+// isPenalized reports whether a rejected submission counts as a rejected
+// attempt. Compilation errors and submissions failing on the first test are
+// not penalized.
+func isPenalized(submission Submission) bool {
+	switch submission.Verdict {
+	case "OK", "", "TESTING", "COMPILATION_ERROR":
+		return false
+	case "SKIPPED", "CHALLENGED":
+		return true
+	default:
+		return submission.PassedTestCount > 0
+	}
+}
+
+func icpcProblemResult(submissions []Submission, penaltyPerAttempt int) ProblemResult {
+	var result ProblemResult
+
+	for _, submission := range submissions {
+		if submission.Verdict == "OK" {
+			result.Points = 1
+			result.Penalty = submission.RelativeTimeSeconds/60 + penaltyPerAttempt*result.RejectedAttemptCount
+			result.BestSubmissionTimeSeconds = submission.RelativeTimeSeconds
+			break
+		}
+		if isPenalized(submission) {
+			result.RejectedAttemptCount++
+		}
+	}
+
+	return result
+}
+
+func ioiProblemResult(problem Problem, submissions []Submission) ProblemResult {
+	var result ProblemResult
+
+	for _, submission := range submissions {
+		if isPending(submission) || submission.Verdict == "COMPILATION_ERROR" {
+			continue
+		}
+
+		points := submission.Points
+		if submission.Verdict == "OK" && points == 0 {
+			points = problem.Points
+		}
+
+		if points > result.Points {
+			result.Points = points
+			result.BestSubmissionTimeSeconds = submission.RelativeTimeSeconds
+		} else if result.Points == 0 {
+			result.RejectedAttemptCount++
+		}
+	}
+
+	return result
+}
+
+// cfProblemResult scores a problem under Codeforces rules: the last submission
+// that passed pretests is the one being judged, every earlier penalized
+// submission costs 50 points, and the problem cost decreases linearly to 52% of
+// its initial value by the end of the contest, never going below 30%.
+func cfProblemResult(problem Problem, durationSeconds int, submissions []Submission) ProblemResult {
+	var result ProblemResult
+
+	final := -1
+	for i, submission := range submissions {
+		if submission.Verdict == "OK" || submission.Verdict == "CHALLENGED" || (submission.Testset == "TESTS" && !isPending(submission)) {
+			final = i
+		}
+	}
+
+	if final < 0 {
+		for _, submission := range submissions {
+			if isPenalized(submission) {
+				result.RejectedAttemptCount++
+			}
+		}
+		return result
+	}
+
+	for _, submission := range submissions[:final] {
+		if isPenalized(submission) {
+			result.RejectedAttemptCount++
+		}
+	}
+
+	submission := submissions[final]
+	if submission.Verdict != "OK" {
+		result.RejectedAttemptCount++
+		return result
+	}
+
+	minutes := float64(submission.RelativeTimeSeconds / 60)
+	duration := float64(durationSeconds / 60)
+	if duration == 0 {
+		duration = 120
+	}
+
+	x := problem.Points
+	points := x - math.Floor(120*x*minutes/(250*duration)) - float64(50*result.RejectedAttemptCount)
+
+	result.Points = math.Max(points, math.Floor(0.3*x))
+	result.BestSubmissionTimeSeconds = submission.RelativeTimeSeconds
+
+	return result
+}
+
+// partyKey returns a string uniquely identifying a party within a contest.
+func partyKey(party Party) string {
+	if party.TeamID != 0 {
+		return party.ParticipantType + "/team/" + strconv.Itoa(party.TeamID)
+	}
+
+	handles := make([]string, len(party.Members))
+	for i, member := range party.Members {
+		handles[i] = member.Handle
+	}
+	sort.Strings(handles)
+
+	return party.ParticipantType + "/" + strings.Join(handles, ";")
+}
+
+type ranklistSorter struct {
+	rows      []RanklistRow
+	keys      []string
+	byPenalty bool
+}
+
+func (s *ranklistSorter) Len() int {
+	return len(s.rows)
+}
+
+func (s *ranklistSorter) Less(i, j int) bool {
+	if s.rows[i].Points != s.rows[j].Points {
+		return s.rows[i].Points > s.rows[j].Points
+	}
+	if s.byPenalty && s.rows[i].Penalty != s.rows[j].Penalty {
+		return s.rows[i].Penalty < s.rows[j].Penalty
+	}
+	return s.keys[i] < s.keys[j]
+}
+
+func (s *ranklistSorter) Swap(i, j int) {
+	s.rows[i], s.rows[j] = s.rows[j], s.rows[i]
+	s.keys[i], s.keys[j] = s.keys[j], s.keys[i]
+}
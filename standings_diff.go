@@ -0,0 +1,208 @@
+package codeforces
+
+import (
+	"context"
+	"time"
+)
+
+// StandingsSnapshot represents the standings of a contest at some point in
+// time, as returned by GetContestStandings.
+type StandingsSnapshot struct {
+	Contest  Contest
+	Problems []Problem
+	Rows     []RanklistRow
+}
+
+// StandingsEvent is a change between two standings snapshots. It is one of
+// ProblemSolved, FirstBlood, RankChanged, HackSucceeded or NewParticipant.
+type StandingsEvent interface {
+	standingsEvent()
+}
+
+// ProblemSolved is emitted when a party gets an accepted result for a problem.
+type ProblemSolved struct {
+	Party   Party
+	Problem Problem
+	Result  ProblemResult
+}
+
+// FirstBlood is emitted when a problem is solved for the first time in the
+// contest. It is emitted in addition to ProblemSolved.
+type FirstBlood struct {
+	Party   Party
+	Problem Problem
+	Result  ProblemResult
+}
+
+// RankChanged is emitted when the rank of a party changes.
+type RankChanged struct {
+	Party   Party
+	OldRank int
+	NewRank int
+}
+
+// HackSucceeded is emitted when the number of successful hacks of a party
+// increases. Count is the number of new successful hacks.
+type HackSucceeded struct {
+	Party Party
+	Count int
+}
+
+// NewParticipant is emitted when a party appears in the standings for the
+// first time.
+type NewParticipant struct {
+	Row RanklistRow
+}
+
+func (ProblemSolved) standingsEvent()  {}
+func (FirstBlood) standingsEvent()     {}
+func (RankChanged) standingsEvent()    {}
+func (HackSucceeded) standingsEvent()  {}
+func (NewParticipant) standingsEvent() {}
+
+// DiffStandings compares two standings snapshots of the same contest and
+// returns the events that lead from before to after, in the order of the rows
+// of after.
+func DiffStandings(before, after StandingsSnapshot) []StandingsEvent {
+	oldRows := make(map[string]RanklistRow, len(before.Rows))
+	for _, row := range before.Rows {
+		oldRows[partyKey(row.Party)] = row
+	}
+
+	solvedBefore := make([]bool, len(after.Problems))
+	for _, row := range before.Rows {
+		for i, result := range row.ProblemResults {
+			if i < len(solvedBefore) && isSolved(after.Contest, after.Problems[i], result) {
+				solvedBefore[i] = true
+			}
+		}
+	}
+
+	firstBlood := make([]*FirstBlood, len(after.Problems))
+
+	var events []StandingsEvent
+	for _, row := range after.Rows {
+		oldRow, ok := oldRows[partyKey(row.Party)]
+		if !ok {
+			events = append(events, NewParticipant{Row: row})
+		}
+
+		for i, result := range row.ProblemResults {
+			if i >= len(after.Problems) || !isSolved(after.Contest, after.Problems[i], result) {
+				continue
+			}
+			if ok && i < len(oldRow.ProblemResults) && isSolved(after.Contest, after.Problems[i], oldRow.ProblemResults[i]) {
+				continue
+			}
+
+			events = append(events, ProblemSolved{Party: row.Party, Problem: after.Problems[i], Result: result})
+
+			if !solvedBefore[i] && (firstBlood[i] == nil || result.BestSubmissionTimeSeconds < firstBlood[i].Result.BestSubmissionTimeSeconds) {
+				firstBlood[i] = &FirstBlood{Party: row.Party, Problem: after.Problems[i], Result: result}
+			}
+		}
+
+		if row.SuccessfulHackCount > oldRow.SuccessfulHackCount {
+			events = append(events, HackSucceeded{Party: row.Party, Count: row.SuccessfulHackCount - oldRow.SuccessfulHackCount})
+		}
+
+		if ok && row.Rank != oldRow.Rank {
+			events = append(events, RankChanged{Party: row.Party, OldRank: oldRow.Rank, NewRank: row.Rank})
+		}
+	}
+
+	for _, event := range firstBlood {
+		if event != nil {
+			events = append(events, *event)
+		}
+	}
+
+	return events
+}
+
+// isSolved reports whether result is an accepted result for problem. Partial
+// scores in IOI contests are not considered accepted.
+func isSolved(contest Contest, problem Problem, result ProblemResult) bool {
+	if result.Points <= 0 {
+		return false
+	}
+	if contest.Type == "IOI" && problem.Points > 0 {
+		return result.Points >= problem.Points
+	}
+
+	return true
+}
+
+// defaultPollStandingsInterval is the polling interval of PollStandings when
+// given a non-positive interval.
+const defaultPollStandingsInterval = 30 * time.Second
+
+// PollStandings polls GetContestStandings every interval and sends the events
+// between consecutive snapshots on the returned event channel. The first
+// snapshot is only used as a baseline and produces no events. A non-positive
+// interval defaults to 30 seconds.
+//
+// Set handles to a empty list to not filter by handles.
+//
+// Errors from GetContestStandings are sent on the returned error channel and
+// polling continues on the next tick. Both channels must be drained by the
+// caller and are closed once ctx is done.
+func (c *Client) PollStandings(ctx context.Context, contestID int, handles []string, showUnofficial bool, interval time.Duration) (<-chan StandingsEvent, <-chan error) {
+	if interval <= 0 {
+		interval = defaultPollStandingsInterval
+	}
+
+	events := make(chan StandingsEvent)
+	errs := make(chan error)
+
+	go func() {
+		defer close(events)
+		defer close(errs)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		var last *StandingsSnapshot
+		for {
+			contest, problems, rows, err := c.getContestStandings(ctx, contestID, 1, 0, handles, 0, showUnofficial)
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				select {
+				case errs <- err:
+				case <-ctx.Done():
+					return
+				}
+			} else {
+				snapshot := StandingsSnapshot{Contest: contest, Problems: problems, Rows: rows}
+				if last != nil {
+					for _, event := range DiffStandings(*last, snapshot) {
+						select {
+						case events <- event:
+						case <-ctx.Done():
+							return
+						}
+					}
+				}
+				last = &snapshot
+			}
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return events, errs
+}
+
+// PollStandings polls GetContestStandings every interval and sends the events
+// between consecutive snapshots on the returned event channel.
+//
+// PollStandings is a wrapper around DefaultClient.PollStandings.
+func PollStandings(ctx context.Context, contestID int, handles []string, showUnofficial bool, interval time.Duration) (<-chan StandingsEvent, <-chan error) {
+	return DefaultClient.PollStandings(ctx, contestID, handles, showUnofficial, interval)
+}
@@ -0,0 +1,287 @@
+// Package store keeps a local on-disk mirror of Codeforces data.
+//
+// A Store holds contests, problems, users, rating changes and submissions in
+// plain JSON segment files under a directory, one file per collection, user
+// or contest. Files are replaced atomically, so a crash during a sync leaves
+// either the previous or the new version of a segment. Sync methods fetch
+// only what changed since the previous sync; query methods read the mirror
+// without any API call and return the types of package codeforces.
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mukundan314/go-codeforces"
+)
+
+// formatVersion is the version of the segment file format.
+const formatVersion = 1
+
+// ErrNotFound is returned by queries for data that is not in the store.
+var ErrNotFound = errors.New("store: not found")
+
+// ErrFormatVersion is returned when a segment file was written by an
+// incompatible version of the store.
+var ErrFormatVersion = errors.New("store: unsupported segment format version")
+
+// Store is a local mirror of Codeforces data. It is safe for concurrent use
+// within a process; a directory must not be shared by several processes.
+type Store struct {
+	dir    string
+	client *codeforces.Client
+	mu     sync.RWMutex
+}
+
+// segment is the content of a segment file.
+type segment struct {
+	Version  int             `json:"version"`
+	SyncedAt int64           `json:"syncedAt"`
+	Data     json.RawMessage `json:"data"`
+}
+
+// problemset is the data of the problems segment.
+type problemset struct {
+	Problems          []codeforces.Problem           `json:"problems"`
+	ProblemStatistics []codeforces.ProblemStatistics `json:"problemStatistics"`
+}
+
+// Open opens the store in dir, creating the directory if needed. Sync methods
+// fetch data through client; set client to nil to use
+// codeforces.DefaultClient.
+func Open(dir string, client *codeforces.Client) (*Store, error) {
+	if client == nil {
+		client = codeforces.DefaultClient
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	return &Store{dir: dir, client: client}, nil
+}
+
+// Dir returns the directory of the store.
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+func contestsPath() string {
+	return "contests.json"
+}
+
+func problemsPath() string {
+	return "problems.json"
+}
+
+func userPath(handle string) string {
+	return filepath.Join("users", handleFile(handle))
+}
+
+func userRatingPath(handle string) string {
+	return filepath.Join("rating", "user", handleFile(handle))
+}
+
+func contestRatingPath(contestID int) string {
+	return filepath.Join("rating", "contest", strconv.Itoa(contestID)+".json")
+}
+
+func userSubmissionsPath(handle string) string {
+	return filepath.Join("submissions", "user", handleFile(handle))
+}
+
+func contestSubmissionsPath(contestID int) string {
+	return filepath.Join("submissions", "contest", strconv.Itoa(contestID)+".json")
+}
+
+// handleFile returns the file name of a handle. Handles are case insensitive.
+func handleFile(handle string) string {
+	return strings.ToLower(handle) + ".json"
+}
+
+// read reads the segment at path into v and returns the time it was synced.
+// It returns ErrNotFound if the segment does not exist.
+func (s *Store) read(path string, v interface{}) (time.Time, error) {
+	b, err := ioutil.ReadFile(filepath.Join(s.dir, path))
+	if os.IsNotExist(err) {
+		return time.Time{}, ErrNotFound
+	} else if err != nil {
+		return time.Time{}, err
+	}
+
+	var seg segment
+	if err := json.Unmarshal(b, &seg); err != nil {
+		return time.Time{}, err
+	}
+	if seg.Version != formatVersion {
+		return time.Time{}, ErrFormatVersion
+	}
+
+	return time.Unix(seg.SyncedAt, 0), json.Unmarshal(seg.Data, v)
+}
+
+// write atomically replaces the segment at path with v: the segment is
+// written to a temporary file in the same directory, synced and renamed.
+func (s *Store) write(path string, v interface{}, syncedAt time.Time) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(segment{Version: formatVersion, SyncedAt: syncedAt.Unix(), Data: data})
+	if err != nil {
+		return err
+	}
+
+	path = filepath.Join(s.dir, path)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	f, err := ioutil.TempFile(filepath.Dir(path), ".tmp-"+filepath.Base(path))
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), path)
+}
+
+// Contests returns the stored contests, including gym contests.
+func (s *Store) Contests() ([]codeforces.Contest, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var contests []codeforces.Contest
+	_, err := s.read(contestsPath(), &contests)
+	return contests, err
+}
+
+// Contest returns the stored contest with the given ID.
+func (s *Store) Contest(contestID int) (codeforces.Contest, error) {
+	contests, err := s.Contests()
+	if err != nil {
+		return codeforces.Contest{}, err
+	}
+
+	for _, c := range contests {
+		if c.ID == contestID {
+			return c, nil
+		}
+	}
+	return codeforces.Contest{}, ErrNotFound
+}
+
+// Problems returns the stored problems of the problemset and their
+// statistics.
+func (s *Store) Problems() ([]codeforces.Problem, []codeforces.ProblemStatistics, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var ps problemset
+	_, err := s.read(problemsPath(), &ps)
+	return ps.Problems, ps.ProblemStatistics, err
+}
+
+// User returns the stored user with the given handle.
+func (s *Store) User(handle string) (codeforces.User, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var user codeforces.User
+	_, err := s.read(userPath(handle), &user)
+	return user, err
+}
+
+// Users returns every stored user, sorted by handle.
+func (s *Store) Users() ([]codeforces.User, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	files, err := ioutil.ReadDir(filepath.Join(s.dir, "users"))
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var users []codeforces.User
+	for _, fi := range files {
+		if !strings.HasSuffix(fi.Name(), ".json") || strings.HasPrefix(fi.Name(), ".") {
+			continue
+		}
+
+		var user codeforces.User
+		if _, err := s.read(filepath.Join("users", fi.Name()), &user); err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+
+	sort.Slice(users, func(i, j int) bool {
+		return strings.ToLower(users[i].Handle) < strings.ToLower(users[j].Handle)
+	})
+	return users, nil
+}
+
+// UserRating returns the stored rating history of a user.
+func (s *Store) UserRating(handle string) ([]codeforces.RatingChange, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var changes []codeforces.RatingChange
+	_, err := s.read(userRatingPath(handle), &changes)
+	return changes, err
+}
+
+// ContestRatingChanges returns the stored rating changes of a contest.
+func (s *Store) ContestRatingChanges(contestID int) ([]codeforces.RatingChange, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var changes []codeforces.RatingChange
+	_, err := s.read(contestRatingPath(contestID), &changes)
+	return changes, err
+}
+
+// UserSubmissions returns the stored submissions of a user, most recent
+// first.
+func (s *Store) UserSubmissions(handle string) ([]codeforces.Submission, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var submissions []codeforces.Submission
+	_, err := s.read(userSubmissionsPath(handle), &submissions)
+	return submissions, err
+}
+
+// ContestSubmissions returns the stored submissions of a contest, most recent
+// first.
+func (s *Store) ContestSubmissions(contestID int) ([]codeforces.Submission, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var submissions []codeforces.Submission
+	_, err := s.read(contestSubmissionsPath(contestID), &submissions)
+	return submissions, err
+}
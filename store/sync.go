@@ -0,0 +1,352 @@
+package store
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/mukundan314/go-codeforces"
+)
+
+// syncPageSize is the number of submissions fetched per API call by
+// incremental submission syncs.
+const syncPageSize = 1000
+
+// userInfoBatchSize is the number of handles per user.info call.
+const userInfoBatchSize = 500
+
+// ratingUpdateDelay is how long after the end of a contest rating histories
+// keep being refetched, as rating changes are published some time after a
+// contest finishes.
+const ratingUpdateDelay = 48 * time.Hour
+
+// PhaseChange is a contest whose phase changed between two syncs. OldPhase is
+// empty for contests that were not in the store.
+type PhaseChange struct {
+	Contest  codeforces.Contest
+	OldPhase string
+}
+
+// SyncContests refetches the list of contests, including gym contests, and
+// returns the contests whose phase changed since the previous sync.
+func (s *Store) SyncContests() ([]PhaseChange, error) {
+	contests, err := s.client.GetAllContestList()
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var stored []codeforces.Contest
+	if _, err := s.read(contestsPath(), &stored); err != nil && err != ErrNotFound {
+		return nil, err
+	}
+
+	phases := make(map[int]string, len(stored))
+	for _, c := range stored {
+		phases[c.ID] = c.Phase
+	}
+
+	var changes []PhaseChange
+	for _, c := range contests {
+		if old, ok := phases[c.ID]; !ok || old != c.Phase {
+			changes = append(changes, PhaseChange{Contest: c, OldPhase: old})
+		}
+	}
+
+	return changes, s.write(contestsPath(), contests, time.Now())
+}
+
+// SyncProblems refetches the problems of the problemset.
+func (s *Store) SyncProblems() error {
+	problems, stats, err := s.client.GetProblemsetProblems(nil, "")
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.write(problemsPath(), problemset{problems, stats}, time.Now())
+}
+
+// SyncUsers refetches the users with the given handles.
+func (s *Store) SyncUsers(handles []string) error {
+	for len(handles) > 0 {
+		n := userInfoBatchSize
+		if n > len(handles) {
+			n = len(handles)
+		}
+
+		users, err := s.client.GetUserInfo(handles[:n])
+		if err != nil {
+			return err
+		}
+		handles = handles[n:]
+
+		s.mu.Lock()
+		now := time.Now()
+		for _, user := range users {
+			if err := s.write(userPath(user.Handle), user, now); err != nil {
+				s.mu.Unlock()
+				return err
+			}
+		}
+		s.mu.Unlock()
+	}
+
+	return nil
+}
+
+// SyncUserRating refetches the rating history of a user if it is not stored,
+// or if a contest finished less than two days before the previous sync. Sync
+// contests first so that finished contests are known.
+func (s *Store) SyncUserRating(handle string) error {
+	s.mu.RLock()
+	var stored []codeforces.RatingChange
+	syncedAt, err := s.read(userRatingPath(handle), &stored)
+	var contests []codeforces.Contest
+	if err == nil {
+		_, err = s.read(contestsPath(), &contests)
+	}
+	s.mu.RUnlock()
+
+	switch err {
+	case nil:
+		if !finishedSince(contests, syncedAt.Add(-ratingUpdateDelay)) {
+			return nil
+		}
+	case ErrNotFound:
+	default:
+		return err
+	}
+
+	changes, err := s.client.GetUserRating(handle)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.write(userRatingPath(handle), changes, time.Now())
+}
+
+// finishedSince reports whether a finished contest ended after t.
+func finishedSince(contests []codeforces.Contest, t time.Time) bool {
+	for _, c := range contests {
+		end := time.Unix(int64(c.StartTimeSeconds+c.DurationSeconds), 0)
+		if c.Phase == "FINISHED" && end.After(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// SyncContestRatingChanges fetches the rating changes of a finished contest.
+// They are stored once published; contests that are known not to be finished
+// are skipped.
+func (s *Store) SyncContestRatingChanges(contestID int) error {
+	s.mu.RLock()
+	var stored []codeforces.RatingChange
+	_, err := s.read(contestRatingPath(contestID), &stored)
+	s.mu.RUnlock()
+
+	if err != ErrNotFound {
+		return err
+	}
+
+	contest, err := s.Contest(contestID)
+	if err == nil && contest.Phase != "FINISHED" {
+		return nil
+	} else if err != nil && err != ErrNotFound {
+		return err
+	}
+
+	changes, err := s.client.GetContestRatingChanges(contestID)
+	if err != nil || len(changes) == 0 {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.write(contestRatingPath(contestID), changes, time.Now())
+}
+
+// SyncUserSubmissions fetches the submissions of a user made since the
+// previous sync and refetches those that were not final, and returns the
+// number of new or refetched submissions.
+//
+// A stored submission is not final while its verdict is pending or while its
+// contest is known to be in a phase other than FINISHED, as system tests may
+// still change its verdict.
+func (s *Store) SyncUserSubmissions(handle string) (int, error) {
+	return s.syncSubmissions(userSubmissionsPath(handle), func(from, count int) ([]codeforces.Submission, error) {
+		return s.client.GetUserStatus(handle, from, count)
+	})
+}
+
+// SyncContestSubmissions fetches the submissions of a contest made since the
+// previous sync and refetches those that were not final, and returns the
+// number of new or refetched submissions. See SyncUserSubmissions.
+func (s *Store) SyncContestSubmissions(contestID int) (int, error) {
+	return s.syncSubmissions(contestSubmissionsPath(contestID), func(from, count int) ([]codeforces.Submission, error) {
+		return s.client.GetContestStatus(contestID, "", from, count)
+	})
+}
+
+func (s *Store) syncSubmissions(path string, fetch func(from, count int) ([]codeforces.Submission, error)) (int, error) {
+	s.mu.RLock()
+	var stored []codeforces.Submission
+	_, err := s.read(path, &stored)
+	var contests []codeforces.Contest
+	if err == nil {
+		if _, err = s.read(contestsPath(), &contests); err == ErrNotFound {
+			err = nil
+		}
+	}
+	s.mu.RUnlock()
+
+	if err != nil && err != ErrNotFound {
+		return 0, err
+	}
+
+	var fetched []codeforces.Submission
+	if len(stored) == 0 {
+		if fetched, err = fetch(1, 0); err != nil {
+			return 0, err
+		}
+	} else {
+		boundary := syncBoundary(stored, contests)
+		for from := 1; ; from += syncPageSize {
+			page, err := fetch(from, syncPageSize)
+			if err != nil {
+				return 0, err
+			}
+			fetched = append(fetched, page...)
+
+			if len(page) < syncPageSize || page[len(page)-1].ID < boundary {
+				break
+			}
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Re-read under the write lock, in case of a concurrent sync.
+	stored = nil
+	if _, err := s.read(path, &stored); err != nil && err != ErrNotFound {
+		return 0, err
+	}
+
+	byID := make(map[int]codeforces.Submission, len(stored)+len(fetched))
+	for _, sub := range stored {
+		byID[sub.ID] = sub
+	}
+	for _, sub := range fetched {
+		byID[sub.ID] = sub
+	}
+
+	merged := make([]codeforces.Submission, 0, len(byID))
+	for _, sub := range byID {
+		merged = append(merged, sub)
+	}
+	sort.Slice(merged, func(i, j int) bool {
+		return merged[i].ID > merged[j].ID
+	})
+
+	return len(fetched), s.write(path, merged, time.Now())
+}
+
+// syncBoundary returns the lowest submission ID that must be refetched: the
+// highest stored ID, or the lowest ID of a stored submission that is not
+// final.
+func syncBoundary(stored []codeforces.Submission, contests []codeforces.Contest) int {
+	phases := make(map[int]string, len(contests))
+	for _, c := range contests {
+		phases[c.ID] = c.Phase
+	}
+
+	boundary := 0
+	for _, sub := range stored {
+		if sub.ID > boundary {
+			boundary = sub.ID
+		}
+	}
+
+	for _, sub := range stored {
+		phase, ok := phases[sub.ContestID]
+		final := sub.Verdict != "" && sub.Verdict != "TESTING" && (!ok || phase == "FINISHED")
+		if !final && sub.ID < boundary {
+			boundary = sub.ID
+		}
+	}
+
+	return boundary
+}
+
+// Sync refreshes the whole mirror for the given handles: contests, problems,
+// users, rating histories and submissions. Contest submissions already in the
+// store are refetched when their phase changes, and the rating changes of
+// contests that finished during the last two days are fetched.
+func (s *Store) Sync(handles []string) error {
+	changes, err := s.SyncContests()
+	if err != nil {
+		return err
+	}
+
+	if err := s.SyncProblems(); err != nil {
+		return err
+	}
+
+	if err := s.SyncUsers(handles); err != nil {
+		return err
+	}
+
+	for _, handle := range handles {
+		if err := s.SyncUserRating(handle); err != nil {
+			return err
+		}
+		if _, err := s.SyncUserSubmissions(handle); err != nil {
+			return err
+		}
+	}
+
+	for _, change := range changes {
+		if change.OldPhase == "" {
+			continue
+		}
+
+		if _, err := s.ContestSubmissions(change.Contest.ID); err == nil {
+			if _, err := s.SyncContestSubmissions(change.Contest.ID); err != nil {
+				return err
+			}
+		} else if err != ErrNotFound {
+			return err
+		}
+	}
+
+	contests, err := s.Contests()
+	if err != nil {
+		return err
+	}
+
+	since := time.Now().Add(-ratingUpdateDelay)
+	for _, c := range contests {
+		if !finishedSince([]codeforces.Contest{c}, since) || c.ID >= 100000 {
+			continue
+		}
+
+		// Gym contests are skipped above; other unrated contests fail with
+		// an API error.
+		var apiErr *codeforces.APIError
+		if err := s.SyncContestRatingChanges(c.ID); err != nil && !errors.As(err, &apiErr) {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -161,24 +161,30 @@ type Submission struct {
 	PassedTestCount     int     `json:"passedTestCount"`
 	TimeConsumedMillis  int     `json:"timeConsumedMillis"`
 	MemoryConsumedBytes int     `json:"memoryConsumedBytes"`
+	Points              float64 `json:"points,omitempty"`
 }
 
 // Hack represents a hack, made during Codeforces Round.
 //
 // Codeforces API docs: https://codeforces.com/apiHelp/objects#Hack
 type Hack struct {
-	ID                  int     `json:"id"`
-	CreationTimeSeconds int     `json:"creationTimeSeconds"`
-	Hacker              Party   `json:"hacker"`
-	Defender            Party   `json:"defender"`
-	Verdict             string  `json:"verdict,omitempty"`
-	Problem             Problem `json:"problem"`
-	Test                string  `json:"test,omitempty"`
-	JudgeProtocol       struct {
-		Manual   string `json:"manual"`
-		Protocol string `json:"protocol"`
-		Verdict  string `json:"verdict"`
-	} `json:"judgeProtocol,omitempty"`
+	ID                  int           `json:"id"`
+	CreationTimeSeconds int           `json:"creationTimeSeconds"`
+	Hacker              Party         `json:"hacker"`
+	Defender            Party         `json:"defender"`
+	Verdict             string        `json:"verdict,omitempty"`
+	Problem             Problem       `json:"problem"`
+	Test                string        `json:"test,omitempty"`
+	JudgeProtocol       JudgeProtocol `json:"judgeProtocol,omitempty"`
+}
+
+// JudgeProtocol represents the localized judge protocol of a hack.
+//
+// Codeforces API docs: https://codeforces.com/apiHelp/objects#Hack
+type JudgeProtocol struct {
+	Manual   string `json:"manual"`
+	Protocol string `json:"protocol"`
+	Verdict  string `json:"verdict"`
 }
 
 // RanklistRow represents a ranklist row.
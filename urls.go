@@ -0,0 +1,101 @@
+package codeforces
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// siteURL is the URL of the Codeforces website.
+const siteURL = "https://codeforces.com"
+
+// isGym reports whether contestID is the ID of a gym contest.
+func isGym(contestID int) bool {
+	return contestID >= 100000
+}
+
+// contestPath returns the path of a contest, which depends on whether it is a
+// gym contest.
+func contestPath(contestID int) string {
+	if isGym(contestID) {
+		return fmt.Sprintf("/gym/%d", contestID)
+	}
+	return fmt.Sprintf("/contest/%d", contestID)
+}
+
+// ContestURL returns the URL of a contest.
+func ContestURL(contestID int) string {
+	return siteURL + contestPath(contestID)
+}
+
+// StandingsURL returns the URL of the standings of a contest.
+func StandingsURL(contestID int) string {
+	return siteURL + contestPath(contestID) + "/standings"
+}
+
+// ProblemURL returns the URL of a problem.
+func ProblemURL(id ProblemID) string {
+	if id.ContestID == 0 {
+		return fmt.Sprintf("%s/problemsets/%s/problem/99999/%s", siteURL, id.ProblemsetName, id.Index)
+	}
+	return fmt.Sprintf("%s%s/problem/%s", siteURL, contestPath(id.ContestID), id.Index)
+}
+
+// SubmissionURL returns the URL of a submission. Set contestID to 0 for
+// submissions to a non-contest problemset.
+func SubmissionURL(contestID int, problemsetName string, submissionID int) string {
+	if contestID == 0 {
+		return fmt.Sprintf("%s/problemsets/%s/submission/99999/%d", siteURL, problemsetName, submissionID)
+	}
+	return fmt.Sprintf("%s%s/submission/%d", siteURL, contestPath(contestID), submissionID)
+}
+
+// BlogEntryURL returns the URL of a blog entry.
+func BlogEntryURL(blogEntryID int) string {
+	return fmt.Sprintf("%s/blog/entry/%d", siteURL, blogEntryID)
+}
+
+// CommentURL returns the URL of a comment to a blog entry.
+func CommentURL(blogEntryID, commentID int) string {
+	return fmt.Sprintf("%s#comment-%d", BlogEntryURL(blogEntryID), commentID)
+}
+
+// ProfileURL returns the URL of the profile of a user.
+func ProfileURL(handle string) string {
+	return siteURL + "/profile/" + url.PathEscape(handle)
+}
+
+// URL returns the URL of the contest.
+func (c Contest) URL() string {
+	return ContestURL(c.ID)
+}
+
+// StandingsURL returns the URL of the standings of the contest.
+func (c Contest) StandingsURL() string {
+	return StandingsURL(c.ID)
+}
+
+// URL returns the URL of the problem.
+func (p Problem) URL() string {
+	return ProblemURL(p.ProblemID())
+}
+
+// URL returns the URL of the submission.
+func (s Submission) URL() string {
+	return SubmissionURL(s.ContestID, s.Problem.ProblemsetName, s.ID)
+}
+
+// URL returns the URL of the blog entry.
+func (b BlogEntry) URL() string {
+	return BlogEntryURL(b.ID)
+}
+
+// URL returns the URL of the comment. Comments do not know the blog entry they
+// belong to, so it has to be given.
+func (c Comment) URL(blogEntryID int) string {
+	return CommentURL(blogEntryID, c.ID)
+}
+
+// URL returns the URL of the profile of the user.
+func (u User) URL() string {
+	return ProfileURL(u.Handle)
+}
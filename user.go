@@ -1,6 +1,8 @@
 package codeforces
 
 import (
+	"context"
+	"encoding/json"
 	"strconv"
 )
 
@@ -96,6 +98,34 @@ func GetUserRatedList(activeOnly bool) ([]User, error) {
 	return DefaultClient.GetUserRatedList(activeOnly)
 }
 
+// StreamUserRatedList calls f with each user who has participated in at least
+// one rated contest, decoding users one at a time instead of returning the
+// whole list. Stops at the first error returned by f.
+//
+// Codeforces API docs: https://codeforces.com/apiHelp/methods#user.ratedList
+func (c *Client) StreamUserRatedList(activeOnly bool, f func(User) error) error {
+	params := make(map[string][]string)
+	params["activeOnly"] = []string{strconv.FormatBool(activeOnly)}
+
+	return c.makeStreamingAPICall("user.ratedList", params, func(dec *json.Decoder) error {
+		var user User
+		if err := dec.Decode(&user); err != nil {
+			return err
+		}
+		return f(user)
+	})
+}
+
+// StreamUserRatedList calls f with each user who has participated in at least
+// one rated contest.
+//
+// StreamUserRatedList is a wrapper around DefaultClient.StreamUserRatedList.
+//
+// Codeforces API docs: https://codeforces.com/apiHelp/methods#user.ratedList
+func StreamUserRatedList(activeOnly bool, f func(User) error) error {
+	return DefaultClient.StreamUserRatedList(activeOnly, f)
+}
+
 // GetUserRating returns rating history of the specified user.
 //
 // Codeforces API docs: https://codeforces.com/apiHelp/methods#user.rating
@@ -124,6 +154,10 @@ func GetUserRating(handle string) ([]RatingChange, error) {
 //
 // Codeforces API docs: https://codeforces.com/apiHelp/methods#user.status
 func (c *Client) GetUserStatus(handle string, from, count int) ([]Submission, error) {
+	return c.getUserStatus(context.Background(), handle, from, count)
+}
+
+func (c *Client) getUserStatus(ctx context.Context, handle string, from, count int) ([]Submission, error) {
 	params := make(map[string][]string)
 	params["handle"] = []string{handle}
 	params["from"] = []string{strconv.FormatInt(int64(from), 10)}
@@ -132,7 +166,7 @@ func (c *Client) GetUserStatus(handle string, from, count int) ([]Submission, er
 	}
 
 	var res []Submission
-	err := c.makeAPICall("user.status", params, &res)
+	err := c.makeAPICallContext(ctx, "user.status", params, &res)
 
 	return res, err
 }
@@ -0,0 +1,227 @@
+package codeforces
+
+import (
+	"context"
+	"time"
+)
+
+// defaultWatcherCount is the number of most recent submissions fetched on each
+// poll by a Watcher.
+const defaultWatcherCount = 10
+
+// defaultWatcherInterval is the polling interval of a Watcher created with a
+// non-positive interval.
+const defaultWatcherInterval = 10 * time.Second
+
+// Watcher polls the submissions of a user or of a contest and reports each
+// submission once, when it reaches a final verdict.
+//
+// Submissions that were already judged when the watcher started are not
+// reported. API calls go through the client and therefore honor its rate
+// limit.
+type Watcher struct {
+	client    *Client
+	handle    string
+	contestID int
+	interval  time.Duration
+	count     int
+
+	onVerdict []func(Submission)
+	onError   []func(error)
+
+	started bool
+	pending map[int]bool
+
+	// lastID is the highest ID of the submissions seen. Submissions up to
+	// lastID have been reported or were judged before the watcher started,
+	// except for the pending ones.
+	lastID int
+}
+
+// NewUserWatcher creates a Watcher for the submissions of handle, polling
+// GetUserStatus every interval. A non-positive interval defaults to 10
+// seconds.
+func (c *Client) NewUserWatcher(handle string, interval time.Duration) *Watcher {
+	if interval <= 0 {
+		interval = defaultWatcherInterval
+	}
+
+	return &Watcher{
+		client:   c,
+		handle:   handle,
+		interval: interval,
+		count:    defaultWatcherCount,
+		pending:  make(map[int]bool),
+	}
+}
+
+// NewUserWatcher creates a Watcher for the submissions of handle, polling
+// GetUserStatus every interval.
+//
+// NewUserWatcher is a wrapper around DefaultClient.NewUserWatcher.
+func NewUserWatcher(handle string, interval time.Duration) *Watcher {
+	return DefaultClient.NewUserWatcher(handle, interval)
+}
+
+// NewContestWatcher creates a Watcher for the submissions of a contest, polling
+// GetContestStatus every interval. A non-positive interval defaults to 10
+// seconds.
+//
+// Set handle to a empty string to watch submissions of all handles.
+func (c *Client) NewContestWatcher(contestID int, handle string, interval time.Duration) *Watcher {
+	w := c.NewUserWatcher(handle, interval)
+	w.contestID = contestID
+	return w
+}
+
+// NewContestWatcher creates a Watcher for the submissions of a contest, polling
+// GetContestStatus every interval.
+//
+// NewContestWatcher is a wrapper around DefaultClient.NewContestWatcher.
+func NewContestWatcher(contestID int, handle string, interval time.Duration) *Watcher {
+	return DefaultClient.NewContestWatcher(contestID, handle, interval)
+}
+
+// SetCount sets the number of most recent submissions fetched on each poll.
+// Set count to 0 to fetch all submissions on each poll. Older pages are
+// fetched as long as a pending submission or a submission newer than the
+// previous poll has not been seen.
+func (w *Watcher) SetCount(count int) {
+	w.count = count
+}
+
+// OnVerdict registers a callback invoked with every submission that reaches a
+// final verdict. Callbacks are invoked sequentially from the goroutine running
+// Run.
+func (w *Watcher) OnVerdict(f func(Submission)) {
+	w.onVerdict = append(w.onVerdict, f)
+}
+
+// OnError registers a callback invoked with every error returned while
+// polling. The watcher keeps polling after an error.
+func (w *Watcher) OnError(f func(error)) {
+	w.onError = append(w.onError, f)
+}
+
+// Run polls submissions until ctx is done.
+func (w *Watcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := w.poll(ctx); err != nil && ctx.Err() == nil {
+			for _, f := range w.onError {
+				f(err)
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Submissions runs the watcher in a new goroutine and returns a channel
+// receiving every submission that reaches a final verdict. The channel is
+// closed once ctx is done.
+func (w *Watcher) Submissions(ctx context.Context) <-chan Submission {
+	ch := make(chan Submission)
+
+	w.OnVerdict(func(submission Submission) {
+		select {
+		case ch <- submission:
+		case <-ctx.Done():
+		}
+	})
+
+	go func() {
+		defer close(ch)
+		w.Run(ctx)
+	}()
+
+	return ch
+}
+
+func (w *Watcher) fetch(ctx context.Context, from int) ([]Submission, error) {
+	if w.contestID != 0 {
+		return w.client.getContestStatus(ctx, w.contestID, w.handle, from, w.count)
+	}
+	return w.client.getUserStatus(ctx, w.handle, from, w.count)
+}
+
+// poll fetches pages of submissions, newest first, until every pending
+// submission and every submission made since the previous poll has been seen.
+func (w *Watcher) poll(ctx context.Context) error {
+	var judged []Submission
+
+	seen := make(map[int]bool)
+	lastID := w.lastID
+	for from := 1; ; from += w.count {
+		submissions, err := w.fetch(ctx, from)
+		if err != nil {
+			return err
+		}
+
+		for _, submission := range submissions {
+			seen[submission.ID] = true
+			if submission.ID > lastID {
+				lastID = submission.ID
+			}
+
+			switch {
+			case isPending(submission):
+				if !w.started || submission.ID > w.lastID {
+					w.pending[submission.ID] = true
+				}
+			case !w.started:
+			case w.pending[submission.ID] || submission.ID > w.lastID:
+				judged = append(judged, submission)
+			}
+		}
+
+		if w.count <= 0 || len(submissions) < w.count {
+			break
+		}
+		oldest := submissions[len(submissions)-1].ID
+		if !w.hasUnseenPending(seen) && (!w.started || oldest <= w.lastID) {
+			break
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+
+	// Pending submissions missing from the complete history were deleted.
+	for id := range w.pending {
+		if !seen[id] {
+			delete(w.pending, id)
+		}
+	}
+
+	w.started = true
+	w.lastID = lastID
+
+	// Submissions are returned newest first, report them oldest first.
+	for i := len(judged) - 1; i >= 0; i-- {
+		submission := judged[i]
+
+		delete(w.pending, submission.ID)
+
+		for _, f := range w.onVerdict {
+			f(submission)
+		}
+	}
+
+	return nil
+}
+
+func (w *Watcher) hasUnseenPending(seen map[int]bool) bool {
+	for id := range w.pending {
+		if !seen[id] {
+			return true
+		}
+	}
+	return false
+}